@@ -0,0 +1,106 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_BATCH_CONCURRENCY is default number of concurrent requests in batch
+// operations
+const DEFAULT_BATCH_CONCURRENCY = 4
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// BatchResult contains result of processing single batch item
+type BatchResult[T any] struct {
+	Value T
+	Err   error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// BatchCreate creates conferences concurrently. Results are returned in input
+// order. If context is canceled, items which were not processed contain context
+// error.
+func (c *Client) BatchCreate(ctx context.Context, confs []*Conference, concurrency int) []BatchResult[*ConferenceInfo] {
+	return runBatch(ctx, confs, concurrency, c.CreateContext)
+}
+
+// BatchGet fetches info about conferences concurrently. Results are returned in
+// input order. If context is canceled, items which were not processed contain
+// context error.
+func (c *Client) BatchGet(ctx context.Context, ids []string, concurrency int) []BatchResult[*ConferenceInfo] {
+	return runBatch(ctx, ids, concurrency, c.GetContext)
+}
+
+// BatchDelete cancels conferences concurrently. Errors are returned in input
+// order. If context is canceled, items which were not processed contain context
+// error.
+func (c *Client) BatchDelete(ctx context.Context, ids []string, concurrency int) []error {
+	results := runBatch(ctx, ids, concurrency, func(ctx context.Context, id string) (struct{}, error) {
+		return struct{}{}, c.DeleteContext(ctx, id)
+	})
+
+	errs := make([]error, len(results))
+
+	for i, r := range results {
+		errs[i] = r.Err
+	}
+
+	return errs
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// runBatch processes items using pool of workers
+func runBatch[I, O any](ctx context.Context, items []I, concurrency int, fn func(context.Context, I) (O, error)) []BatchResult[O] {
+	results := make([]BatchResult[O], len(items))
+
+	if len(items) == 0 {
+		return results
+	}
+
+	if concurrency <= 0 {
+		concurrency = DEFAULT_BATCH_CONCURRENCY
+	}
+
+	concurrency = min(concurrency, len(items))
+
+	queue := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for range concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				if ctx.Err() != nil {
+					results[i].Err = ctx.Err()
+					continue
+				}
+
+				results[i].Value, results[i].Err = fn(ctx, items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		queue <- i
+	}
+
+	close(queue)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,286 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_CACHE_SIZE is default maximum number of cache entries
+const DEFAULT_CACHE_SIZE = 1024
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Cache is read-through cache for conference info and cohosts with TTL and LRU
+// eviction. Cache can be shared between clients with different base URLs, but
+// must not be shared between clients with different tokens.
+type Cache struct {
+	ttl      time.Duration
+	size     int
+	entries  map[string]*list.Element
+	lru      *list.List
+	inflight map[string]*cacheCall
+	stats    CacheStats
+	mu       sync.Mutex
+}
+
+// CacheStats contains cache usage statistics
+type CacheStats struct {
+	Hits      int64 // Number of requests served from cache
+	Misses    int64 // Number of requests sent to API
+	Shared    int64 // Number of requests which waited for concurrent fetch
+	Evictions int64 // Number of entries evicted due to size limit
+}
+
+// cacheEntry contains cached response
+type cacheEntry struct {
+	key     string
+	id      string
+	data    []byte
+	expires time.Time
+}
+
+// cacheCall contains info about in-flight request
+type cacheCall struct {
+	id    string
+	done  chan struct{}
+	data  []byte
+	err   error
+	stale bool // Conference data was invalidated during request
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewCache creates new cache with given entry TTL and maximum number of entries
+// (DEFAULT_CACHE_SIZE is used if size is less than 1)
+func NewCache(ttl time.Duration, size int) (*Cache, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("Cache TTL must be greater than 0 (%s)", ttl)
+	}
+
+	if size < 1 {
+		size = DEFAULT_CACHE_SIZE
+	}
+
+	return &Cache{
+		ttl:      ttl,
+		size:     size,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		inflight: make(map[string]*cacheCall),
+	}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Invalidate removes all cached data for conference with given ID
+func (c *Cache) Invalidate(id string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, call := range c.inflight {
+		if call.id == id {
+			c.markStale(key, call)
+		}
+	}
+
+	for e := c.lru.Front(); e != nil; {
+		next := e.Next()
+
+		if e.Value.(*cacheEntry).id == id {
+			c.remove(e)
+		}
+
+		e = next
+	}
+}
+
+// Purge removes all cached data
+func (c *Cache) Purge() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, call := range c.inflight {
+		c.markStale(key, call)
+	}
+
+	c.lru.Init()
+	clear(c.entries)
+}
+
+// Len returns number of cached entries
+func (c *Cache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Stats returns cache usage statistics
+func (c *Cache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// middleware returns middleware for client which serves read requests from cache
+// and invalidates cache on mutations. Base URL of client is requested for every
+// request, so changes of default API URL don't lead to serving of stale data.
+func (c *Cache) middleware(baseURL func() string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			switch r.Operation {
+			case OP_GET, OP_GET_COHOSTS:
+				return c.read(ctx, baseURL, r, next)
+
+			case OP_UPDATE, OP_DELETE, OP_ADD_COHOSTS, OP_UPDATE_COHOSTS, OP_DELETE_COHOSTS:
+				err := next(ctx, r)
+				c.Invalidate(r.ID)
+				return err
+			}
+
+			return next(ctx, r)
+		}
+	}
+}
+
+// read serves request from cache or fetches data using given handler
+func (c *Cache) read(ctx context.Context, baseURL func() string, r *Request, next Handler) error {
+	key := r.Operation + ":" + baseURL() + r.Endpoint + "?" + encodeQuery(r.Query)
+
+	c.mu.Lock()
+
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*cacheEntry)
+
+		if time.Now().Before(entry.expires) {
+			c.lru.MoveToFront(e)
+			c.stats.Hits++
+			c.mu.Unlock()
+
+			return json.Unmarshal(entry.data, r.Response)
+		}
+
+		c.remove(e)
+	}
+
+	if call, ok := c.inflight[key]; ok {
+		c.stats.Shared++
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Can't send request to API: %w", ctx.Err())
+		case <-call.done:
+		}
+
+		// Shared fetch was interrupted by context of another caller or its
+		// result was invalidated while we were waiting for it
+		if call.stale || (isContextError(call.err) && ctx.Err() == nil) {
+			return c.read(ctx, baseURL, r, next)
+		}
+
+		if call.err != nil {
+			return call.err
+		}
+
+		return json.Unmarshal(call.data, r.Response)
+	}
+
+	call := &cacheCall{id: r.ID, done: make(chan struct{})}
+
+	c.inflight[key] = call
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	call.err = next(ctx, r)
+
+	if call.err == nil {
+		call.data, call.err = json.Marshal(r.Response)
+	}
+
+	c.mu.Lock()
+
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+	}
+
+	// Don't store data if cache was invalidated during request
+	if call.err == nil && !call.stale {
+		c.add(key, r.ID, call.data)
+	}
+
+	c.mu.Unlock()
+
+	close(call.done)
+
+	return call.err
+}
+
+// add adds new entry to cache
+func (c *Cache) add(key, id string, data []byte) {
+	c.entries[key] = c.lru.PushFront(&cacheEntry{
+		key:     key,
+		id:      id,
+		data:    data,
+		expires: time.Now().Add(c.ttl),
+	})
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// markStale marks in-flight request as stale, so its result won't be stored, new
+// readers won't wait for it and readers which already wait for it will fetch
+// data again
+func (c *Cache) markStale(key string, call *cacheCall) {
+	call.stale = true
+	delete(c.inflight, key)
+}
+
+// remove removes entry from cache
+func (c *Cache) remove(e *list.Element) {
+	c.lru.Remove(e)
+	delete(c.entries, e.Value.(*cacheEntry).key)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isContextError returns true if given error is caused by context cancellation
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
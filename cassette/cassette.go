@@ -0,0 +1,425 @@
+// Package cassette provides transport for recording and replaying API requests
+package cassette
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Mode is recorder mode
+type Mode uint8
+
+const (
+	MODE_REPLAY Mode = iota // Replay recorded interactions
+	MODE_RECORD             // Send requests to API and record interactions
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Cassette contains recorded interactions
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions" yaml:"interactions"`
+}
+
+// Interaction contains recorded request and response
+type Interaction struct {
+	Request  *Request  `json:"request" yaml:"request"`
+	Response *Response `json:"response" yaml:"response"`
+}
+
+// Request contains info about recorded request
+type Request struct {
+	Method string `json:"method" yaml:"method"`
+	URL    string `json:"url" yaml:"url"`
+	Body   string `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// Response contains info about recorded response
+type Response struct {
+	StatusCode int               `json:"status_code" yaml:"status_code"`
+	Header     map[string]string `json:"header,omitempty" yaml:"header,omitempty"`
+	Body       string            `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// Recorder is HTTP transport which records or replays interactions with API
+type Recorder struct {
+	// Transport is transport used for sending requests in record mode
+	// (http.DefaultTransport is used if nil)
+	Transport http.RoundTripper
+
+	path     string
+	mode     Mode
+	cassette *Cassette
+	used     []bool
+	mu       sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrEmptyPath   = fmt.Errorf("Cassette path is empty")
+	ErrNilRecorder = fmt.Errorf("Recorder is nil")
+)
+
+// emailRegex is regex for matching emails
+var emailRegex = regexp.MustCompile(`([A-Za-z0-9._%+\-]+)@([A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)+)`)
+
+// scrubbedRegex is regex for matching email pseudonyms
+var scrubbedRegex = regexp.MustCompile(`^user-[0-9a-f]{8}@example\.com$`)
+
+// skippedHeaders is a set of response headers which are not recorded
+var skippedHeaders = map[string]bool{
+	"Date":           true,
+	"Set-Cookie":     true,
+	"Content-Length": true,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// New creates new recorder for cassette with given path. Cassette format is
+// defined by file extension (.json, .yaml or .yml). In replay mode cassette is
+// loaded from the file.
+func New(path string, mode Mode) (*Recorder, error) {
+	if path == "" {
+		return nil, ErrEmptyPath
+	}
+
+	err := checkFormat(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{path: path, mode: mode, cassette: &Cassette{}}
+
+	if mode == MODE_RECORD {
+		return r, nil
+	}
+
+	r.cassette, err = Load(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Load loads cassette from file
+func Load(path string) (*Cassette, error) {
+	err := checkFormat(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read cassette: %w", err)
+	}
+
+	c := &Cassette{}
+
+	if isJSON(path) {
+		err = json.Unmarshal(data, c)
+	} else {
+		err = yaml.Unmarshal(data, c)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode cassette: %w", err)
+	}
+
+	return c, nil
+}
+
+// Scrub replaces all emails in given string with deterministic pseudonyms.
+// Pseudonyms are kept as is, so data can be scrubbed multiple times.
+func Scrub(data string) string {
+	return emailRegex.ReplaceAllStringFunc(data, func(email string) string {
+		if scrubbedRegex.MatchString(email) {
+			return email
+		}
+
+		hash := sha256.Sum256([]byte(strings.ToLower(email)))
+		return "user-" + hex.EncodeToString(hash[:4]) + "@example.com"
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RoundTrip implements http.RoundTripper interface
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r == nil || r.cassette == nil {
+		return nil, ErrNilRecorder
+	}
+
+	body, err := readBody(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	recReq := &Request{
+		Method: req.Method,
+		URL:    normalizeURL(req.URL),
+		Body:   normalizeBody(body),
+	}
+
+	if r.mode == MODE_RECORD {
+		return r.record(req, recReq, body)
+	}
+
+	return r.replay(req, recReq)
+}
+
+// Save saves recorded interactions to cassette file
+func (r *Recorder) Save() error {
+	if r == nil || r.cassette == nil {
+		return ErrNilRecorder
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var data []byte
+	var err error
+
+	if isJSON(r.path) {
+		data, err = json.MarshalIndent(r.cassette, "", "  ")
+	} else {
+		data, err = yaml.Marshal(r.cassette)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Can't encode cassette: %w", err)
+	}
+
+	err = os.WriteFile(r.path, data, 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't save cassette: %w", err)
+	}
+
+	return nil
+}
+
+// Unused returns interactions which were not replayed
+func (r *Recorder) Unused() []*Interaction {
+	if r == nil || r.cassette == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []*Interaction
+
+	for i, used := range r.used {
+		if !used {
+			result = append(result, r.cassette.Interactions[i])
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// record sends request to API and records interaction. Client receives scrubbed
+// response, so code under test behaves the same way in both modes.
+func (r *Recorder) record(req *http.Request, recReq *Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	out := req.Clone(req.Context())
+
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := transport.RoundTrip(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read response body: %w", err)
+	}
+
+	recResp := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     make(map[string]string),
+		Body:       Scrub(string(respBody)),
+	}
+
+	for name := range resp.Header {
+		if !skippedHeaders[name] {
+			recResp.Header[name] = resp.Header.Get(name)
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{recReq, recResp})
+	r.mu.Unlock()
+
+	return makeResponse(req, recResp), nil
+}
+
+// replay finds recorded interaction for given request
+func (r *Recorder) replay(req *http.Request, recReq *Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, inter := range r.cassette.Interactions {
+		if r.used[i] || !isMatch(inter.Request, recReq) {
+			continue
+		}
+
+		r.used[i] = true
+
+		return makeResponse(req, inter.Response), nil
+	}
+
+	return nil, fmt.Errorf(
+		"Cassette %s doesn't contain interaction for request %s %s",
+		filepath.Base(r.path), recReq.Method, recReq.URL,
+	)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isMatch returns true if recorded request matches given one
+func isMatch(recorded, req *Request) bool {
+	return recorded != nil &&
+		recorded.Method == req.Method &&
+		recorded.URL == req.URL &&
+		normalizeBody([]byte(recorded.Body)) == req.Body
+}
+
+// makeResponse creates HTTP response from recorded response
+func makeResponse(req *http.Request, recResp *Response) *http.Response {
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recResp.StatusCode, http.StatusText(recResp.StatusCode)),
+		StatusCode:    recResp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(strings.NewReader(recResp.Body)),
+		ContentLength: int64(len(recResp.Body)),
+		Request:       req,
+	}
+
+	for name, value := range recResp.Header {
+		resp.Header.Set(name, value)
+	}
+
+	return resp
+}
+
+// readBody reads request body
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	defer req.Body.Close()
+
+	data, err := io.ReadAll(req.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read request body: %w", err)
+	}
+
+	return data, nil
+}
+
+// normalizeURL returns scrubbed path and query with sorted parameters
+func normalizeURL(u *url.URL) string {
+	result := u.Path
+
+	if u.RawQuery != "" {
+		result += "?" + u.Query().Encode()
+	}
+
+	unescaped, err := url.QueryUnescape(result)
+
+	if err == nil {
+		result = unescaped
+	}
+
+	return Scrub(result)
+}
+
+// normalizeBody returns scrubbed body with stable JSON formatting
+func normalizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	body = []byte(Scrub(string(body)))
+
+	var v any
+
+	if json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+
+	// Map keys are sorted by encoder
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return string(body)
+	}
+
+	return string(data)
+}
+
+// checkFormat checks cassette file format
+func checkFormat(path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported cassette format %q", filepath.Ext(path))
+}
+
+// isJSON returns true if cassette is stored in JSON format
+func isJSON(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".json"
+}
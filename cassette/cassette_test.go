@@ -0,0 +1,138 @@
+package cassette
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/telemosttest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type CassetteSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&CassetteSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *CassetteSuite) TestRecordReplay(c *C) {
+	for _, file := range []string{"cassette.yaml", "cassette.json"} {
+		path := c.MkDir() + "/" + file
+
+		srv := telemosttest.NewServer("Secret1234")
+		rec, err := New(path, MODE_RECORD)
+
+		c.Assert(err, IsNil)
+
+		api, _ := telemost.NewClient("Secret1234", telemost.WithBaseURL(srv.URL), telemost.WithTransport(rec))
+		runScenario(c, api)
+
+		srv.Close()
+
+		c.Assert(rec.Save(), IsNil)
+
+		data, err := os.ReadFile(path)
+
+		c.Assert(err, IsNil)
+		c.Assert(strings.Contains(string(data), "Secret1234"), Equals, false)
+		c.Assert(strings.Contains(string(data), "john"), Equals, false)
+		c.Assert(strings.Contains(string(data), Scrub("john@yandex.ru")), Equals, true)
+
+		cassette, err := Load(path)
+
+		c.Assert(err, IsNil)
+		c.Assert(cassette.Interactions, HasLen, 6)
+
+		rec, err = New(path, MODE_REPLAY)
+
+		c.Assert(err, IsNil)
+
+		api, _ = telemost.NewClient("Other", telemost.WithBaseURL("http://127.0.0.1:1"), telemost.WithTransport(rec))
+		runScenario(c, api)
+
+		c.Assert(rec.Unused(), HasLen, 0)
+
+		_, err = api.Get("99999999999999")
+		c.Assert(err, ErrorMatches, `.*Cassette `+file+` doesn't contain interaction for request GET /99999999999999`)
+	}
+}
+
+func (s *CassetteSuite) TestScrub(c *C) {
+	c.Assert(Scrub("john@yandex.ru, JOHN@yandex.ru"), Equals, "user-d4453dfe@example.com, user-d4453dfe@example.com")
+	c.Assert(Scrub("user-d4453dfe@example.com"), Equals, "user-d4453dfe@example.com")
+	c.Assert(Scrub("no emails"), Equals, "no emails")
+}
+
+func (s *CassetteSuite) TestErrors(c *C) {
+	_, err := New("", MODE_REPLAY)
+	c.Assert(err, Equals, ErrEmptyPath)
+
+	_, err = New("test.txt", MODE_RECORD)
+	c.Assert(err, ErrorMatches, `Unsupported cassette format ".txt"`)
+
+	_, err = New("/_unknown_.yaml", MODE_REPLAY)
+	c.Assert(err, ErrorMatches, `Can't read cassette: .*`)
+
+	path := c.MkDir() + "/broken.json"
+	os.WriteFile(path, []byte("{"), 0644)
+
+	_, err = New(path, MODE_REPLAY)
+	c.Assert(err, ErrorMatches, `Can't decode cassette: .*`)
+
+	_, err = Load("test.txt")
+	c.Assert(err, NotNil)
+
+	rec, _ := New(c.MkDir()+"/test.yaml", MODE_RECORD)
+	rec.path = "/_unknown_/test.yaml"
+	c.Assert(rec.Save(), ErrorMatches, `Can't save cassette: .*`)
+
+	rec.Transport = http.DefaultTransport
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL("http://127.0.0.1:1"), telemost.WithTransport(rec))
+	_, err = api.Get("1")
+	c.Assert(err, NotNil)
+
+	var nilRec *Recorder
+
+	_, err = nilRec.RoundTrip(nil)
+	c.Assert(err, Equals, ErrNilRecorder)
+	c.Assert(nilRec.Save(), Equals, ErrNilRecorder)
+	c.Assert(nilRec.Unused(), IsNil)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func runScenario(c *C, api *telemost.Client) {
+	info, err := api.Create(&telemost.Conference{WaitingRoomLevel: telemost.ROOM_LEVEL_ADMINS})
+
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "10000000000001")
+	c.Assert(info.WaitingRoomLevel, Equals, telemost.ROOM_LEVEL_ADMINS)
+
+	c.Assert(api.AddCohosts(info.ID, []string{"john@yandex.ru", "bob@yandex.ru"}), IsNil)
+
+	cohosts, err := api.GetCohosts(info.ID)
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts.Flatten(), DeepEquals, []string{Scrub("john@yandex.ru"), Scrub("bob@yandex.ru")})
+
+	c.Assert(api.DeleteCohosts(info.ID, []string{"bob@yandex.ru"}), IsNil)
+
+	_, err = api.Get("1")
+	c.Assert(err, NotNil)
+}
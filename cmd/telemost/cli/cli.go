@@ -0,0 +1,515 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/knf"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+	"github.com/essentialkaos/ek/v13/usage"
+
+	"github.com/essentialkaos/telemost"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Application basic info
+const (
+	APP  = "telemost"
+	VER  = "0.2.0"
+	DESC = "Tool for managing Yandex.Telemost conferences"
+)
+
+// Options
+const (
+	OPT_TOKEN         = "t:token"
+	OPT_CONFIG        = "c:config"
+	OPT_OUTPUT        = "o:output"
+	OPT_WAITING_ROOM  = "W:waiting-room"
+	OPT_STREAM_ACCESS = "A:stream-access"
+	OPT_STREAM_TITLE  = "T:stream-title"
+	OPT_STREAM_DESC   = "D:stream-description"
+	OPT_COHOST        = "C:cohost"
+	OPT_NO_COLOR      = "nc:no-color"
+	OPT_HELP          = "h:help"
+	OPT_VER           = "v:version"
+)
+
+// Commands
+const (
+	CMD_CREATE  = "create"
+	CMD_GET     = "get"
+	CMD_UPDATE  = "update"
+	CMD_DELETE  = "delete"
+	CMD_COHOSTS = "cohosts"
+
+	CMD_COHOSTS_LIST   = "list"
+	CMD_COHOSTS_ADD    = "add"
+	CMD_COHOSTS_SET    = "set"
+	CMD_COHOSTS_REMOVE = "remove"
+)
+
+// Output formats
+const (
+	FORMAT_TABLE = "table"
+	FORMAT_JSON  = "json"
+	FORMAT_YAML  = "yaml"
+)
+
+// ENV_TOKEN is name of environment variable with OAuth token
+const ENV_TOKEN = "TELEMOST_TOKEN"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// optMap contains information about all supported options
+var optMap = options.Map{
+	OPT_TOKEN:         {},
+	OPT_CONFIG:        {},
+	OPT_OUTPUT:        {Value: FORMAT_TABLE},
+	OPT_WAITING_ROOM:  {},
+	OPT_STREAM_ACCESS: {},
+	OPT_STREAM_TITLE:  {},
+	OPT_STREAM_DESC:   {},
+	OPT_COHOST:        {Mergeble: true},
+	OPT_NO_COLOR:      {Type: options.BOOL},
+	OPT_HELP:          {Type: options.BOOL},
+	OPT_VER:           {Type: options.MIXED},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run is main application function
+func Run() {
+	args, errs := options.Parse(optMap)
+
+	if !errs.IsEmpty() {
+		terminal.Error("Options parsing errors:")
+		terminal.Error(errs.Error(" - "))
+		os.Exit(1)
+	}
+
+	configureUI()
+
+	switch {
+	case options.Has(OPT_VER):
+		genAbout().Print(options.GetS(OPT_VER))
+		os.Exit(0)
+	case options.GetB(OPT_HELP) || len(args) == 0:
+		genUsage().Print()
+		os.Exit(0)
+	}
+
+	err := process(args)
+
+	if err != nil {
+		terminal.Error(err)
+		os.Exit(1)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// configureUI configures user interface
+func configureUI() {
+	if options.GetB(OPT_NO_COLOR) {
+		fmtc.DisableColors = true
+	}
+}
+
+// process starts command processing
+func process(args options.Arguments) error {
+	format := strings.ToLower(options.GetS(OPT_OUTPUT))
+
+	switch format {
+	case FORMAT_TABLE, FORMAT_JSON, FORMAT_YAML:
+		// ok
+	default:
+		return fmt.Errorf("Unsupported output format %q", format)
+	}
+
+	api, err := getClient()
+
+	if err != nil {
+		return err
+	}
+
+	cmd := args.Get(0).ToLower().String()
+	cmdArgs := args[1:]
+
+	switch cmd {
+	case CMD_CREATE:
+		return cmdCreate(api, format)
+	case CMD_GET:
+		return cmdGet(api, cmdArgs, format)
+	case CMD_UPDATE:
+		return cmdUpdate(api, cmdArgs, format)
+	case CMD_DELETE:
+		return cmdDelete(api, cmdArgs, format)
+	case CMD_COHOSTS:
+		return processCohostsCommand(api, cmdArgs, format)
+	}
+
+	return fmt.Errorf("Unknown command %q", cmd)
+}
+
+// processCohostsCommand processes cohosts subcommands
+func processCohostsCommand(api *telemost.Client, args options.Arguments, format string) error {
+	cmd := args.Get(0).ToLower().String()
+
+	if cmd == "" {
+		return fmt.Errorf("Cohosts command is required")
+	}
+
+	switch cmd {
+	case CMD_COHOSTS_LIST:
+		return cmdCohostsList(api, args[1:], format)
+	case CMD_COHOSTS_ADD, CMD_COHOSTS_SET, CMD_COHOSTS_REMOVE:
+		return cmdCohostsModify(api, cmd, args[1:], format)
+	}
+
+	return fmt.Errorf("Unknown cohosts command %q", cmd)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdCreate is handler for "create" command
+func cmdCreate(api *telemost.Client, format string) error {
+	conf, err := getConference()
+
+	if err != nil {
+		return err
+	}
+
+	info, err := api.Create(conf)
+
+	if err != nil {
+		return err
+	}
+
+	return printConference(os.Stdout, info, format)
+}
+
+// cmdGet is handler for "get" command
+func cmdGet(api *telemost.Client, args options.Arguments, format string) error {
+	if !args.Has(0) {
+		return fmt.Errorf("Conference ID is required")
+	}
+
+	info, err := api.Get(args.Get(0).String())
+
+	if err != nil {
+		return err
+	}
+
+	return printConference(os.Stdout, info, format)
+}
+
+// cmdUpdate is handler for "update" command
+func cmdUpdate(api *telemost.Client, args options.Arguments, format string) error {
+	if !args.Has(0) {
+		return fmt.Errorf("Conference ID is required")
+	}
+
+	patch, err := getPatch()
+
+	if err != nil {
+		return err
+	}
+
+	info, err := api.Patch(args.Get(0).String(), patch)
+
+	if err != nil {
+		return err
+	}
+
+	return printConference(os.Stdout, info, format)
+}
+
+// cmdDelete is handler for "delete" command
+func cmdDelete(api *telemost.Client, args options.Arguments, format string) error {
+	if !args.Has(0) {
+		return fmt.Errorf("Conference ID is required")
+	}
+
+	ref := args.Get(0).String()
+	err := api.Delete(ref)
+
+	if err != nil {
+		return err
+	}
+
+	printStatus(format, "Conference {*}%s{!} deleted", ref)
+
+	return nil
+}
+
+// cmdCohostsList is handler for "cohosts list" command
+func cmdCohostsList(api *telemost.Client, args options.Arguments, format string) error {
+	if !args.Has(0) {
+		return fmt.Errorf("Conference ID is required")
+	}
+
+	cohosts, err := api.GetCohosts(args.Get(0).String())
+
+	if err != nil {
+		return err
+	}
+
+	return printCohosts(os.Stdout, cohosts, format)
+}
+
+// cmdCohostsModify is handler for "cohosts add", "cohosts set" and "cohosts remove"
+// commands
+func cmdCohostsModify(api *telemost.Client, cmd string, args options.Arguments, format string) error {
+	switch {
+	case !args.Has(0):
+		return fmt.Errorf("Conference ID is required")
+	case !args.Has(1):
+		return fmt.Errorf("At least one cohost email is required")
+	}
+
+	var err error
+
+	ref := args.Get(0).String()
+	emails := args[1:].Strings()
+
+	switch cmd {
+	case CMD_COHOSTS_ADD:
+		err = api.AddCohosts(ref, emails)
+	case CMD_COHOSTS_SET:
+		err = api.UpdateCohosts(ref, emails)
+	case CMD_COHOSTS_REMOVE:
+		err = api.DeleteCohosts(ref, emails)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	printStatus(format, "Cohosts of conference {*}%s{!} updated", ref)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getClient creates API client
+func getClient() (*telemost.Client, error) {
+	token, err := getToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := telemost.NewClient(token, telemost.WithRetryPolicy(telemost.DefaultRetryPolicy))
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.SetUserAgent(APP, VER)
+
+	return api, nil
+}
+
+// getToken returns OAuth token from options, environment variable or configuration
+// file
+func getToken() (string, error) {
+	if options.Has(OPT_TOKEN) {
+		return options.GetS(OPT_TOKEN), nil
+	}
+
+	if os.Getenv(ENV_TOKEN) != "" {
+		return os.Getenv(ENV_TOKEN), nil
+	}
+
+	configFile := options.GetS(OPT_CONFIG)
+
+	if configFile == "" {
+		configDir, err := os.UserConfigDir()
+
+		if err != nil {
+			return "", fmt.Errorf("Token is not set")
+		}
+
+		configFile = filepath.Join(configDir, "telemost", "telemost.knf")
+
+		if _, err = os.Stat(configFile); err != nil {
+			return "", fmt.Errorf(
+				"Token is not set (use %s option, %s environment variable or configuration file)",
+				options.F(OPT_TOKEN), ENV_TOKEN,
+			)
+		}
+	}
+
+	cfg, err := knf.Read(configFile)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't read configuration file: %w", err)
+	}
+
+	token := cfg.GetS("auth:token")
+
+	if token == "" {
+		return "", fmt.Errorf("Configuration file %s doesn't contain auth:token property", configFile)
+	}
+
+	return token, nil
+}
+
+// getConference creates conference using options
+func getConference() (*telemost.Conference, error) {
+	conf := &telemost.Conference{}
+
+	if options.Has(OPT_WAITING_ROOM) {
+		level, err := telemost.ParseWaitingRoomLevel(options.GetS(OPT_WAITING_ROOM))
+
+		if err != nil {
+			return nil, err
+		}
+
+		conf.WaitingRoomLevel = level
+	}
+
+	if options.Has(OPT_STREAM_ACCESS) || options.Has(OPT_STREAM_TITLE) || options.Has(OPT_STREAM_DESC) {
+		conf.LiveStream = &telemost.LiveStream{
+			Title:       options.GetS(OPT_STREAM_TITLE),
+			Description: options.GetS(OPT_STREAM_DESC),
+		}
+
+		if options.Has(OPT_STREAM_ACCESS) {
+			level, err := telemost.ParseAccessLevel(options.GetS(OPT_STREAM_ACCESS))
+
+			if err != nil {
+				return nil, err
+			}
+
+			conf.LiveStream.AccessLevel = level
+		}
+	}
+
+	if options.Has(OPT_COHOST) {
+		conf.WithCohosts(options.Split(OPT_COHOST)...)
+	}
+
+	return conf, nil
+}
+
+// getPatch creates conference patch using options. Only fields set by options
+// are changed.
+func getPatch() (*telemost.ConferencePatch, error) {
+	patch := telemost.NewPatch()
+
+	if options.Has(OPT_WAITING_ROOM) {
+		level, err := telemost.ParseWaitingRoomLevel(options.GetS(OPT_WAITING_ROOM))
+
+		if err != nil {
+			return nil, err
+		}
+
+		patch.SetWaitingRoomLevel(level)
+	}
+
+	if options.Has(OPT_STREAM_ACCESS) {
+		level, err := telemost.ParseAccessLevel(options.GetS(OPT_STREAM_ACCESS))
+
+		if err != nil {
+			return nil, err
+		}
+
+		patch.SetAccessLevel(level)
+	}
+
+	if options.Has(OPT_STREAM_TITLE) {
+		patch.SetTitle(options.GetS(OPT_STREAM_TITLE))
+	}
+
+	if options.Has(OPT_STREAM_DESC) {
+		patch.SetDescription(options.GetS(OPT_STREAM_DESC))
+	}
+
+	if options.Has(OPT_COHOST) {
+		patch.SetCohosts(options.Split(OPT_COHOST)...)
+	}
+
+	if patch.IsEmpty() {
+		return nil, fmt.Errorf("Nothing to update (at least one conference option is required)")
+	}
+
+	return patch, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// genUsage generates usage info
+func genUsage() *usage.Info {
+	info := usage.NewInfo("", "command")
+
+	info.AddCommand(CMD_CREATE, "Create new conference")
+	info.AddCommand(CMD_GET, "Show info about conference", "conference")
+	info.AddCommand(CMD_UPDATE, "Update conference", "conference")
+	info.AddCommand(CMD_DELETE, "Delete conference", "conference")
+	info.AddCommand(CMD_COHOSTS+" "+CMD_COHOSTS_LIST, "List conference cohosts", "conference")
+	info.AddCommand(CMD_COHOSTS+" "+CMD_COHOSTS_ADD, "Add cohosts to conference", "conference", "email…")
+	info.AddCommand(CMD_COHOSTS+" "+CMD_COHOSTS_SET, "Replace conference cohosts", "conference", "email…")
+	info.AddCommand(CMD_COHOSTS+" "+CMD_COHOSTS_REMOVE, "Remove cohosts from conference", "conference", "email…")
+
+	info.AddOption(OPT_TOKEN, "OAuth token", "token")
+	info.AddOption(OPT_CONFIG, "Path to configuration file", "file")
+	info.AddOption(OPT_OUTPUT, "Output format {s-}(table/json/yaml){!}", "format")
+	info.AddOption(OPT_WAITING_ROOM, "Waiting room level {s-}(public/organization/admins){!}", "level")
+	info.AddOption(OPT_STREAM_ACCESS, "Live stream access level {s-}(public/organization){!}", "level")
+	info.AddOption(OPT_STREAM_TITLE, "Live stream title", "title")
+	info.AddOption(OPT_STREAM_DESC, "Live stream description", "description")
+	info.AddOption(OPT_COHOST, "Cohost email {s-}(can be used multiple times){!}", "email")
+	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
+	info.AddOption(OPT_HELP, "Show this help message")
+	info.AddOption(OPT_VER, "Show version")
+
+	info.AddEnv(ENV_TOKEN, "OAuth token")
+
+	info.AddExample(
+		CMD_CREATE+" --waiting-room admins --cohost john@domain.com",
+		"Create new conference with waiting room for everyone except admins",
+	)
+
+	info.AddExample(
+		CMD_GET+" 12345678901234 --output json",
+		"Show info about conference in JSON format",
+	)
+
+	info.AddExample(
+		CMD_DELETE+" https://telemost.yandex.ru/j/12345678901234",
+		"Delete conference using join URL",
+	)
+
+	info.AddExample(
+		CMD_COHOSTS+" "+CMD_COHOSTS_ADD+" 12345678901234 john@domain.com bob@domain.com",
+		"Add two cohosts to conference",
+	)
+
+	return info
+}
+
+// genAbout generates info about version
+func genAbout() *usage.About {
+	return &usage.About{
+		App:     APP,
+		Version: VER,
+		Desc:    DESC,
+		Year:    2025,
+		Owner:   "ESSENTIAL KAOS",
+		License: "Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>",
+	}
+}
@@ -0,0 +1,127 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/telemosttest"
+
+	chk "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { chk.TestingT(t) }
+
+type CLISuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = chk.Suite(&CLISuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *CLISuite) TestUpdate(c *chk.C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+
+	info, err := api.Create(&telemost.Conference{
+		WaitingRoomLevel: telemost.ROOM_LEVEL_ADMINS,
+		LiveStream: &telemost.LiveStream{
+			AccessLevel: telemost.ACCESS_LEVEL_ORG,
+			Title:       "Standup",
+			Description: "Daily standup",
+		},
+	})
+
+	c.Assert(err, chk.IsNil)
+
+	args := parseArgs(c, CMD_UPDATE, info.ID, "--stream-access", "public")
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+
+	err = cmdUpdate(api, args[1:], FORMAT_JSON)
+
+	os.Stdout.Close()
+	os.Stdout = stdout
+
+	c.Assert(err, chk.IsNil)
+
+	conf, ok := srv.Conference(info.ID)
+
+	c.Assert(ok, chk.Equals, true)
+	c.Assert(conf.WaitingRoomLevel, chk.Equals, string(telemost.ROOM_LEVEL_ADMINS))
+	c.Assert(conf.LiveStream, chk.NotNil)
+	c.Assert(conf.LiveStream.AccessLevel, chk.Equals, string(telemost.ACCESS_LEVEL_PUBLIC))
+	c.Assert(conf.LiveStream.Title, chk.Equals, "Standup")
+	c.Assert(conf.LiveStream.Description, chk.Equals, "Daily standup")
+}
+
+func (s *CLISuite) TestOutput(c *chk.C) {
+	fmtc.DisableColors = true
+
+	info := &telemost.ConferenceInfo{
+		ID:      "12345678901234",
+		JoinURL: "https://telemost.yandex.ru/j/12345678901234",
+		Conference: telemost.Conference{
+			WaitingRoomLevel: telemost.ROOM_LEVEL_PUBLIC,
+			LiveStream:       &telemost.LiveStream{Title: "Тест {r}"},
+		},
+	}
+
+	info.WithCohosts("user1@yandex.ru", "user2@yandex.ru")
+
+	var buf bytes.Buffer
+
+	c.Assert(printConference(&buf, info, FORMAT_TABLE), chk.IsNil)
+	c.Assert(buf.String(), chk.Matches, `(?s)PROPERTY +VALUE\n-+\nID +12345678901234\n.*`)
+	c.Assert(buf.String(), chk.Matches, `(?s).*\nWaiting Room Level +PUBLIC\n.*`)
+	c.Assert(buf.String(), chk.Matches, `(?s).*\nStream Title +Тест \{r\}\n.*`)
+	c.Assert(buf.String(), chk.Matches, `(?s).*\nCohost #2 +user2@yandex.ru\n`)
+
+	buf.Reset()
+
+	c.Assert(printCohosts(&buf, info.CoHosts, FORMAT_TABLE), chk.IsNil)
+	c.Assert(buf.String(), chk.Equals, "#  EMAIL\n--------\n1  user1@yandex.ru\n2  user2@yandex.ru\n")
+
+	buf.Reset()
+
+	c.Assert(printCohosts(&buf, nil, FORMAT_TABLE), chk.IsNil)
+	c.Assert(buf.String(), chk.Equals, "Conference has no cohosts\n")
+
+	buf.Reset()
+
+	c.Assert(printCohosts(&buf, nil, FORMAT_JSON), chk.IsNil)
+	c.Assert(buf.String(), chk.Equals, "[]\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseArgs parses given command-line arguments using application options
+func parseArgs(c *chk.C, args ...string) options.Arguments {
+	osArgs := os.Args
+	os.Args = append([]string{APP}, args...)
+
+	defer func() { os.Args = osArgs }()
+
+	result, errs := options.Parse(optMap)
+
+	c.Assert(errs.IsEmpty(), chk.Equals, true)
+
+	return result
+}
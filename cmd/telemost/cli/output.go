@@ -0,0 +1,170 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/essentialkaos/telemost"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// printConference prints info about conference in given format
+func printConference(w io.Writer, info *telemost.ConferenceInfo, format string) error {
+	if format != FORMAT_TABLE {
+		return encode(w, info, format)
+	}
+
+	rows := [][]string{
+		{"ID", info.ID},
+		{"Join URL", info.JoinURL},
+		{"Waiting Room Level", strutil.Q(info.WaitingRoomLevel.Raw(), "—")},
+		{"SIP URI (Meeting)", strutil.Q(info.SIPURIMeeting, "—")},
+		{"SIP URI (Telemost)", strutil.Q(info.SIPURITelemost, "—")},
+		{"SIP ID", strutil.Q(info.SIPID, "—")},
+	}
+
+	if info.LiveStream != nil {
+		rows = append(rows,
+			[]string{"Stream Watch URL", strutil.Q(info.LiveStream.WatchURL, "—")},
+			[]string{"Stream Access Level", strutil.Q(info.LiveStream.AccessLevel.Raw(), "—")},
+			[]string{"Stream Title", strutil.Q(info.LiveStream.Title, "—")},
+			[]string{"Stream Description", strutil.Q(info.LiveStream.Description, "—")},
+		)
+	}
+
+	for i, cohost := range info.CoHosts {
+		rows = append(rows, []string{fmt.Sprintf("Cohost #%d", i+1), cohost.Email})
+	}
+
+	return renderTable(w, []string{"PROPERTY", "VALUE"}, rows)
+}
+
+// printCohosts prints cohosts in given format
+func printCohosts(w io.Writer, cohosts telemost.Hosts, format string) error {
+	if format != FORMAT_TABLE {
+		if cohosts == nil {
+			cohosts = telemost.Hosts{}
+		}
+
+		return encode(w, cohosts, format)
+	}
+
+	if len(cohosts) == 0 {
+		_, err := fmtc.Fprintln(w, "{s}Conference has no cohosts{!}")
+		return err
+	}
+
+	var rows [][]string
+
+	for i, cohost := range cohosts {
+		rows = append(rows, []string{strconv.Itoa(i + 1), cohost.Email})
+	}
+
+	return renderTable(w, []string{"#", "EMAIL"}, rows)
+}
+
+// printStatus prints status message in table mode
+func printStatus(format, message string, args ...any) {
+	if format != FORMAT_TABLE {
+		return
+	}
+
+	fmtc.Printfn("{g}"+message+"{!}", args...)
+}
+
+// renderTable renders table with given headers and rows to writer
+func renderTable(w io.Writer, headers []string, rows [][]string) error {
+	sizes := make([]int, len(headers))
+
+	for i, header := range headers {
+		sizes[i] = utf8.RuneCountInString(header)
+	}
+
+	for _, row := range rows {
+		for i, value := range row {
+			sizes[i] = max(sizes[i], utf8.RuneCountInString(value))
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmtc.Fprintfn(&buf, "{*}%s{!}", formatTableRow(headers, sizes))
+	fmtc.Fprintfn(&buf, "{s-}%s{!}", strings.Repeat("-", utf8.RuneCountInString(formatTableRow(headers, sizes))))
+
+	// Values are written without fmtc, so braces in data from API are never
+	// interpreted as color tags
+	for _, row := range rows {
+		fmt.Fprintln(&buf, formatTableRow(row, sizes))
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// formatTableRow formats table row aligning values by column sizes
+func formatTableRow(values []string, sizes []int) string {
+	var cells []string
+
+	for i, value := range values {
+		if i == len(values)-1 {
+			cells = append(cells, value)
+			break
+		}
+
+		cells = append(cells, value+strings.Repeat(" ", sizes[i]-utf8.RuneCountInString(value)))
+	}
+
+	return strings.Join(cells, "  ")
+}
+
+// encode encodes data to JSON or YAML
+func encode(w io.Writer, v any, format string) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("Can't encode data: %w", err)
+	}
+
+	if format == FORMAT_JSON {
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	// Use JSON as intermediate representation to keep field names from JSON tags
+	var raw any
+
+	err = json.Unmarshal(data, &raw)
+
+	if err != nil {
+		return fmt.Errorf("Can't encode data: %w", err)
+	}
+
+	data, err = yaml.Marshal(raw)
+
+	if err != nil {
+		return fmt.Errorf("Can't encode data: %w", err)
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
@@ -0,0 +1,18 @@
+package main
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	CLI "github.com/essentialkaos/telemost/cmd/telemost/cli"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func main() {
+	CLI.Run()
+}
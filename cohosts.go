@@ -0,0 +1,148 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CohostsPlan contains changes required to bring conference cohosts to desired state
+type CohostsPlan struct {
+	Desired []string // Normalized desired cohosts emails
+	Add     []string // Emails of cohosts which will be added
+	Remove  []string // Emails of cohosts which will be removed
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsEmpty returns true if plan doesn't contain any changes
+func (p *CohostsPlan) IsEmpty() bool {
+	return p == nil || (len(p.Add) == 0 && len(p.Remove) == 0)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PlanCohosts calculates changes required to bring conference cohosts to desired
+// state without applying them
+func (c *Client) PlanCohosts(ctx context.Context, id string, desired []string) (*CohostsPlan, error) {
+	if c == nil || c.engine == nil {
+		return nil, ErrNilClient
+	}
+
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	desired = normalizeEmails(desired)
+
+	if len(desired) > MAX_COHOSTS {
+		return nil, fmt.Errorf("Too many cohosts (%d > %d)", len(desired), MAX_COHOSTS)
+	}
+
+	current, err := c.GetAllCohosts(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return planCohosts(current.Flatten(), desired), nil
+}
+
+// SyncCohosts brings conference cohosts to desired state
+func (c *Client) SyncCohosts(id string, desired []string) (*CohostsPlan, error) {
+	return c.SyncCohostsContext(context.Background(), id, desired)
+}
+
+// SyncCohostsContext brings conference cohosts to desired state using given context
+func (c *Client) SyncCohostsContext(ctx context.Context, id string, desired []string) (*CohostsPlan, error) {
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := c.PlanCohosts(ctx, id, desired)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case plan.IsEmpty():
+		return plan, nil
+	case len(plan.Remove) == 0:
+		err = c.AddCohostsContext(ctx, id, plan.Add)
+	case len(plan.Add) == 0:
+		err = c.DeleteCohostsContext(ctx, id, plan.Remove)
+	default:
+		err = c.UpdateCohostsContext(ctx, id, plan.Desired)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// planCohosts creates plan based on current and desired cohosts
+func planCohosts(current, desired []string) *CohostsPlan {
+	plan := &CohostsPlan{Desired: desired}
+	index := make(map[string]bool, len(current))
+
+	for _, email := range current {
+		norm := normalizeEmail(email)
+
+		if index[norm] {
+			continue
+		}
+
+		index[norm] = true
+
+		if !slices.Contains(desired, norm) {
+			plan.Remove = append(plan.Remove, email)
+		}
+	}
+
+	for _, email := range desired {
+		if !index[email] {
+			plan.Add = append(plan.Add, email)
+		}
+	}
+
+	return plan
+}
+
+// normalizeEmails normalizes and deduplicates emails
+func normalizeEmails(emails []string) []string {
+	var result []string
+
+	for _, email := range emails {
+		email = normalizeEmail(email)
+
+		if email != "" && !slices.Contains(result, email) {
+			result = append(result, email)
+		}
+	}
+
+	return result
+}
+
+// normalizeEmail normalizes email
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,252 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ICS_METHOD_REQUEST = "REQUEST"
+	ICS_METHOD_CANCEL  = "CANCEL"
+)
+
+// ICS_PRODID is product identifier used in generated calendars
+const ICS_PRODID = "-//ESSENTIAL KAOS//Telemost//EN"
+
+// ICS_MAX_LINE_SIZE is maximum size of content line in octets (RFC 5545 §3.1)
+const ICS_MAX_LINE_SIZE = 75
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Event contains info about calendar event for conference
+type Event struct {
+	UID           string    // Unique event ID (generated from conference ID if empty)
+	Summary       string    // Event summary
+	Description   string    // Event description
+	Start         time.Time // Event start time
+	End           time.Time // Event end time
+	Stamp         time.Time // Time of event creation (current time if empty)
+	Organizer     string    // Organizer email (required)
+	OrganizerName string    // Organizer name
+	Sequence      int       // Revision sequence number
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilConferenceInfo = fmt.Errorf("Conference info is nil")
+	ErrNilEvent          = fmt.Errorf("Event is nil")
+	ErrEmptyEventTime    = fmt.Errorf("Event start or end time is empty")
+	ErrEmptyOrganizer    = fmt.Errorf("Event organizer is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ICS generates iCalendar (RFC 5545) invitation for conference with given method
+// (ICS_METHOD_REQUEST or ICS_METHOD_CANCEL)
+func (i *ConferenceInfo) ICS(event *Event, method string) ([]byte, error) {
+	switch {
+	case i == nil:
+		return nil, ErrNilConferenceInfo
+	case i.ID == "":
+		return nil, ErrEmptyID
+	case event == nil:
+		return nil, ErrNilEvent
+	case event.Start.IsZero() || event.End.IsZero():
+		return nil, ErrEmptyEventTime
+	case event.End.Before(event.Start):
+		return nil, fmt.Errorf("Event end time is before start time")
+	case event.Sequence < 0:
+		return nil, fmt.Errorf("Event sequence must be equal or greater than 0 (%d)", event.Sequence)
+	case event.Organizer == "":
+		return nil, ErrEmptyOrganizer
+	case !isValidICSAddress(event.Organizer):
+		return nil, fmt.Errorf("Invalid organizer email %q", event.Organizer)
+	}
+
+	for _, cohost := range i.CoHosts {
+		if cohost != nil && !isValidICSAddress(cohost.Email) {
+			return nil, fmt.Errorf("Invalid cohost email %q", cohost.Email)
+		}
+	}
+
+	status := "CONFIRMED"
+
+	switch method {
+	case ICS_METHOD_REQUEST:
+		// ok
+	case ICS_METHOD_CANCEL:
+		status = "CANCELLED"
+	default:
+		return nil, fmt.Errorf("Unsupported iCalendar method %q", method)
+	}
+
+	uid := event.UID
+
+	if uid == "" {
+		uid = i.ID + "@telemost.yandex.ru"
+	}
+
+	stamp := event.Stamp
+
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	var buf bytes.Buffer
+
+	writeICSLine(&buf, "BEGIN", "VCALENDAR")
+	writeICSLine(&buf, "VERSION", "2.0")
+	writeICSLine(&buf, "PRODID", ICS_PRODID)
+	writeICSLine(&buf, "CALSCALE", "GREGORIAN")
+	writeICSLine(&buf, "METHOD", method)
+	writeICSLine(&buf, "BEGIN", "VEVENT")
+	writeICSLine(&buf, "UID", escapeICSText(uid))
+	writeICSLine(&buf, "DTSTAMP", formatICSTime(stamp))
+	writeICSLine(&buf, "DTSTART", formatICSTime(event.Start))
+	writeICSLine(&buf, "DTEND", formatICSTime(event.End))
+	writeICSLine(&buf, "SEQUENCE", fmt.Sprint(event.Sequence))
+	writeICSLine(&buf, "STATUS", status)
+
+	if event.Summary != "" {
+		writeICSLine(&buf, "SUMMARY", escapeICSText(event.Summary))
+	}
+
+	writeICSLine(&buf, "DESCRIPTION", escapeICSText(i.icsDescription(event.Description)))
+
+	if i.JoinURL != "" {
+		writeICSLine(&buf, "LOCATION", escapeICSText(i.JoinURL))
+		writeICSLine(&buf, "URL", i.JoinURL)
+	}
+
+	organizer := "ORGANIZER"
+
+	if event.OrganizerName != "" {
+		organizer += ";CN=" + quoteICSParam(event.OrganizerName)
+	}
+
+	writeICSLine(&buf, organizer, "mailto:"+event.Organizer)
+
+	for _, cohost := range i.CoHosts {
+		if cohost == nil || cohost.Email == "" {
+			continue
+		}
+
+		writeICSLine(
+			&buf, "ATTENDEE;CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE",
+			"mailto:"+cohost.Email,
+		)
+	}
+
+	writeICSLine(&buf, "END", "VEVENT")
+	writeICSLine(&buf, "END", "VCALENDAR")
+
+	return buf.Bytes(), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// icsDescription generates event description with connection info
+func (i *ConferenceInfo) icsDescription(desc string) string {
+	var info []string
+
+	if desc != "" {
+		info = append(info, desc, "")
+	}
+
+	if i.JoinURL != "" {
+		info = append(info, "Join URL: "+i.JoinURL)
+	}
+
+	if i.SIPURIMeeting != "" {
+		info = append(info, "SIP URI (Meeting): "+i.SIPURIMeeting)
+	}
+
+	if i.SIPURITelemost != "" {
+		info = append(info, "SIP URI (Telemost): "+i.SIPURITelemost)
+	}
+
+	if i.SIPID != "" {
+		info = append(info, "SIP ID: "+i.SIPID)
+	}
+
+	if i.LiveStream != nil && i.LiveStream.WatchURL != "" {
+		info = append(info, "Live stream: "+i.LiveStream.WatchURL)
+	}
+
+	return strings.Join(info, "\n")
+}
+
+// writeICSLine writes content line with folding
+func writeICSLine(buf *bytes.Buffer, name, value string) {
+	line := name + ":" + value
+	size := 0
+
+	for len(line) > 0 {
+		_, n := utf8.DecodeRuneInString(line)
+
+		// Continuation lines start with a space which is also counted
+		if size+n > ICS_MAX_LINE_SIZE {
+			buf.WriteString("\r\n ")
+			size = 1
+		}
+
+		buf.WriteString(line[:n])
+		line = line[n:]
+		size += n
+	}
+
+	buf.WriteString("\r\n")
+}
+
+// formatICSTime formats time in UTC form
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeICSText escapes TEXT value
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(text)
+}
+
+// isValidICSAddress returns true if email can be safely used in mailto: URI
+func isValidICSAddress(email string) bool {
+	return !strings.ContainsFunc(email, unicode.IsControl)
+}
+
+// quoteICSParam quotes parameter value if required
+func quoteICSParam(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == '"' || r < ' ' {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + value + `"`
+	}
+
+	return value
+}
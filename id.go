@@ -0,0 +1,192 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SIP_ID_LENGTH is length of conference SIP ID
+const SIP_ID_LENGTH = 20
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ConferenceID is validated conference ID
+type ConferenceID string
+
+// SIPResolver resolves conference ID from SIP URI or SIP ID
+//
+// API doesn't provide a way to find conference by its SIP URI or SIP ID, so
+// resolver must look it up in conferences known to application (e.g. in
+// registry). Resolver is consulted on every ResolveConferenceID call, so lookups
+// from different goroutines may overlap.
+type SIPResolver interface {
+	// ResolveSIP returns ID of conference with given SIP URI (without "sip:"
+	// scheme) or SIP ID. It must return ErrUnknownSIP if there is no such
+	// conference.
+	ResolveSIP(ctx context.Context, sip string) (string, error)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrUnknownSIP is returned by SIP resolver if conference with given SIP URI or
+// SIP ID is unknown
+var ErrUnknownSIP = fmt.Errorf("Conference with given SIP URI or SIP ID is unknown")
+
+// joinHosts is a set of hosts used in join URLs
+var joinHosts = map[string]bool{
+	"telemost.yandex.ru":     true,
+	"telemost.yandex.com":    true,
+	"telemost.360.yandex.ru": true,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseConferenceID extracts conference ID from raw ID or join URL
+// (https://telemost.yandex.ru/j/12345678901234). API doesn't fix length of IDs,
+// so any sequence of digits is accepted, except sequences of SIP ID length. SIP
+// URIs and SIP IDs can't be parsed and must be resolved using
+// Client.ResolveConferenceID.
+func ParseConferenceID(ref string) (ConferenceID, error) {
+	ref = strings.TrimSpace(ref)
+
+	switch {
+	case ref == "":
+		return "", ErrEmptyID
+
+	case isJoinURL(ref):
+		return parseJoinURL(ref)
+
+	case isSIPURI(ref):
+		return "", fmt.Errorf("Conference ID can't be parsed from SIP URI %q", ref)
+
+	case isSIPID(ref):
+		return "", fmt.Errorf("Conference ID can't be parsed from SIP ID %q", ref)
+
+	case isDigits(ref):
+		return ConferenceID(ref), nil
+	}
+
+	return "", fmt.Errorf("Invalid conference ID %q", ref)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns conference ID as a string
+func (id ConferenceID) String() string {
+	return string(id)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ResolveConferenceID returns conference ID for given raw ID, join URL, SIP URI
+// (SIPURIMeeting) or SIP ID. SIP URIs and SIP IDs are resolved using SIP resolver
+// set by WithSIPResolver option. All client methods accepting conference ID
+// resolve it using this method, so any of these forms can be passed to them.
+func (c *Client) ResolveConferenceID(ctx context.Context, ref string) (ConferenceID, error) {
+	ref = strings.TrimSpace(ref)
+
+	switch {
+	case c == nil:
+		return "", ErrNilClient
+	case isJoinURL(ref), !isSIPURI(ref) && !isSIPID(ref):
+		return ParseConferenceID(ref)
+	case c.sip == nil:
+		return "", fmt.Errorf("Can't resolve SIP URI or SIP ID %q: SIP resolver is not set", ref)
+	}
+
+	id, err := c.sip.ResolveSIP(ctx, trimSIPScheme(ref))
+
+	if err != nil {
+		return "", fmt.Errorf("Can't resolve conference ID from %q: %w", ref, err)
+	}
+
+	return ParseConferenceID(id)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseJoinURL extracts conference ID from join URL
+func parseJoinURL(ref string) (ConferenceID, error) {
+	u, err := url.Parse(ref)
+
+	if err != nil || !joinHosts[strings.ToLower(u.Hostname())] {
+		return "", fmt.Errorf("Invalid conference join URL %q", ref)
+	}
+
+	id, ok := strings.CutPrefix(strings.TrimRight(u.Path, "/"), "/j/")
+
+	if !ok || !isDigits(id) {
+		return "", fmt.Errorf("Invalid conference join URL %q", ref)
+	}
+
+	return ConferenceID(id), nil
+}
+
+// resolveID resolves conference reference passed to client method
+func (c *Client) resolveID(ctx context.Context, ref string) (string, error) {
+	id, err := c.ResolveConferenceID(ctx, ref)
+
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// isJoinURL returns true if given reference looks like join URL
+func isJoinURL(ref string) bool {
+	ref = strings.ToLower(ref)
+	return strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://")
+}
+
+// isSIPURI returns true if given reference has "sip:" scheme or looks like
+// SIP URI without scheme (<digits>@<host>)
+func isSIPURI(ref string) bool {
+	if strings.HasPrefix(strings.ToLower(ref), "sip:") {
+		return true
+	}
+
+	user, host, ok := strings.Cut(ref, "@")
+
+	return ok && isDigits(user) && host != "" && !strings.ContainsAny(host, "@/")
+}
+
+// isSIPID returns true if given reference looks like SIP ID
+func isSIPID(ref string) bool {
+	return len(ref) == SIP_ID_LENGTH && isDigits(ref)
+}
+
+// trimSIPScheme removes "sip:" scheme from SIP URI
+func trimSIPScheme(ref string) string {
+	if strings.HasPrefix(strings.ToLower(ref), "sip:") {
+		return ref[4:]
+	}
+
+	return ref
+}
+
+// isDigits returns true if given string contains only digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
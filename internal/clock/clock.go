@@ -0,0 +1,40 @@
+// Package clock provides source of current time
+package clock
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Clock is source of current time
+type Clock interface {
+	// Now returns current time
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends current time on the
+	// returned channel
+	After(d time.Duration) <-chan time.Time
+}
+
+// System is clock based on system time
+type System struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Now returns current system time
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse
+func (System) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
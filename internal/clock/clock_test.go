@@ -0,0 +1,62 @@
+package clock
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ClockSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&ClockSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *ClockSuite) TestFake(c *C) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	clk := NewFake(start)
+
+	c.Assert(clk.Now().Equal(start), Equals, true)
+
+	ch1 := clk.After(time.Minute)
+	ch2 := clk.After(time.Hour)
+
+	c.Assert(<-clk.After(0), Equals, start)
+
+	clk.Advance(30 * time.Second)
+	c.Assert(len(ch1), Equals, 0)
+
+	clk.Advance(30 * time.Second)
+	c.Assert(<-ch1, Equals, start.Add(time.Minute))
+	c.Assert(len(ch2), Equals, 0)
+
+	clk.Advance(2 * time.Hour)
+	c.Assert(<-ch2, Equals, start.Add(2*time.Hour+time.Minute))
+	c.Assert(clk.Now().Equal(start.Add(2*time.Hour+time.Minute)), Equals, true)
+}
+
+func (s *ClockSuite) TestSystem(c *C) {
+	var clk Clock = System{}
+
+	c.Assert(clk.Now().IsZero(), Equals, false)
+
+	select {
+	case <-clk.After(time.Millisecond):
+	case <-time.After(time.Second):
+		c.Fatal("System clock After didn't fire")
+	}
+}
@@ -0,0 +1,84 @@
+package clock
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Fake is manually controlled clock for tests
+type Fake struct {
+	now     time.Time
+	waiters []*fakeWaiter
+	mu      sync.Mutex
+}
+
+// fakeWaiter contains info about channel returned by After
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewFake creates new fake clock with given current time
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Now returns current fake time
+func (c *Fake) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After returns channel which receives current fake time once clock is advanced
+// by the duration
+func (c *Fake) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &fakeWaiter{c.now.Add(d), make(chan time.Time, 1)}
+
+	if d <= 0 {
+		w.ch <- c.now
+		return w.ch
+	}
+
+	c.waiters = append(c.waiters, w)
+
+	return w.ch
+}
+
+// Advance moves fake time forward and fires all waiters with passed deadlines
+func (c *Fake) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	var waiters []*fakeWaiter
+
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+
+		w.ch <- c.now
+	}
+
+	c.waiters = waiters
+}
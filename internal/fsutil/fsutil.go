@@ -0,0 +1,84 @@
+// Package fsutil provides helpers for working with files
+package fsutil
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WriteFileAtomic writes data to temporary file in the same directory and then
+// renames it to given path, so readers never see partially written file. Both
+// file and directory are synced to disk, so data survives system crash.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	fd, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	err = writeFile(fd, data, perm)
+
+	if err == nil {
+		err = os.Rename(fd.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(fd.Name())
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeFile writes data to file, syncs and closes it
+func writeFile(fd *os.File, data []byte, perm os.FileMode) error {
+	err := fd.Chmod(perm)
+
+	if err == nil {
+		_, err = fd.Write(data)
+	}
+
+	if err == nil {
+		err = fd.Sync()
+	}
+
+	closeErr := fd.Close()
+
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+// syncDir syncs directory to disk, so rename of file in it becomes durable
+func syncDir(dir string) error {
+	// Directories can't be synced on Windows
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	fd, err := os.Open(dir)
+
+	if err != nil {
+		return err
+	}
+
+	err = fd.Sync()
+	fd.Close()
+
+	return err
+}
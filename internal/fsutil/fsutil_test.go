@@ -0,0 +1,61 @@
+package fsutil
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type FSUtilSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&FSUtilSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *FSUtilSuite) TestWriteFileAtomic(c *C) {
+	dir := c.MkDir()
+	file := dir + "/data.json"
+
+	c.Assert(WriteFileAtomic(file, []byte("test1"), 0600), IsNil)
+	c.Assert(WriteFileAtomic(file, []byte("test2"), 0600), IsNil)
+
+	data, err := os.ReadFile(file)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "test2")
+
+	fi, err := os.Stat(file)
+	c.Assert(err, IsNil)
+	c.Assert(fi.Mode().Perm(), Equals, os.FileMode(0600))
+
+	// Permissions are set exactly and aren't affected by umask
+	c.Assert(WriteFileAtomic(file, []byte("test3"), 0644), IsNil)
+
+	fi, err = os.Stat(file)
+	c.Assert(err, IsNil)
+	c.Assert(fi.Mode().Perm(), Equals, os.FileMode(0644))
+
+	c.Assert(WriteFileAtomic(dir+"/unknown/data.json", []byte("test"), 0600), NotNil)
+
+	// Temporary file is removed if it can't be renamed
+	c.Assert(os.Mkdir(dir+"/dir", 0700), IsNil)
+	c.Assert(os.WriteFile(dir+"/dir/file", nil, 0600), IsNil)
+	c.Assert(WriteFileAtomic(dir+"/dir", []byte("test"), 0600), NotNil)
+
+	entries, err := os.ReadDir(dir)
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 2)
+}
@@ -0,0 +1,177 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WaitingRoomLevel is conference waiting room level. Value received from API is
+// kept as is. Levels unknown to the client fail validation, except UNKNOWN which
+// API itself may return.
+type WaitingRoomLevel string
+
+// AccessLevel is live stream access level. Value received from API is kept as
+// is. Levels unknown to the client fail validation, except UNKNOWN which API
+// itself may return.
+type AccessLevel string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ROOM_LEVEL_PUBLIC  WaitingRoomLevel = "PUBLIC"
+	ROOM_LEVEL_ORG     WaitingRoomLevel = "ORGANIZATION"
+	ROOM_LEVEL_ADMINS  WaitingRoomLevel = "ADMINS"
+	ROOM_LEVEL_UNKNOWN WaitingRoomLevel = "UNKNOWN"
+)
+
+const (
+	ACCESS_LEVEL_PUBLIC  AccessLevel = "PUBLIC"
+	ACCESS_LEVEL_ORG     AccessLevel = "ORGANIZATION"
+	ACCESS_LEVEL_UNKNOWN AccessLevel = "UNKNOWN"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseWaitingRoomLevel parses waiting room level name
+func ParseWaitingRoomLevel(name string) (WaitingRoomLevel, error) {
+	l := WaitingRoomLevel(strings.ToUpper(strings.TrimSpace(name)))
+
+	if !l.IsValid() {
+		return "", fmt.Errorf("Unknown waiting room level %q", name)
+	}
+
+	return l, nil
+}
+
+// ParseAccessLevel parses live stream access level name
+func ParseAccessLevel(name string) (AccessLevel, error) {
+	l := AccessLevel(strings.ToUpper(strings.TrimSpace(name)))
+
+	if !l.IsValid() {
+		return "", fmt.Errorf("Unknown live stream access level %q", name)
+	}
+
+	return l, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsValid returns true if level is one of levels which can be set by client
+func (l WaitingRoomLevel) IsValid() bool {
+	switch l {
+	case ROOM_LEVEL_PUBLIC, ROOM_LEVEL_ORG, ROOM_LEVEL_ADMINS:
+		return true
+	}
+
+	return false
+}
+
+// isAllowed returns true if level can be sent to API
+func (l WaitingRoomLevel) isAllowed() bool {
+	return l.IsValid() || l == ROOM_LEVEL_UNKNOWN
+}
+
+// IsZero returns true if level is not set
+func (l WaitingRoomLevel) IsZero() bool {
+	return l == ""
+}
+
+// String returns level name ("UNKNOWN" for levels unknown to the client)
+func (l WaitingRoomLevel) String() string {
+	if l.IsZero() || l.IsValid() {
+		return string(l)
+	}
+
+	return string(ROOM_LEVEL_UNKNOWN)
+}
+
+// Raw returns level name as it was received from API
+func (l WaitingRoomLevel) Raw() string {
+	return string(l)
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (l WaitingRoomLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(l))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (l *WaitingRoomLevel) UnmarshalJSON(data []byte) error {
+	var name string
+
+	err := json.Unmarshal(data, &name)
+
+	if err != nil {
+		return fmt.Errorf("Can't decode waiting room level: %w", err)
+	}
+
+	*l = WaitingRoomLevel(name)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsValid returns true if level is one of levels which can be set by client
+func (l AccessLevel) IsValid() bool {
+	switch l {
+	case ACCESS_LEVEL_PUBLIC, ACCESS_LEVEL_ORG:
+		return true
+	}
+
+	return false
+}
+
+// isAllowed returns true if level can be sent to API
+func (l AccessLevel) isAllowed() bool {
+	return l.IsValid() || l == ACCESS_LEVEL_UNKNOWN
+}
+
+// IsZero returns true if level is not set
+func (l AccessLevel) IsZero() bool {
+	return l == ""
+}
+
+// String returns level name ("UNKNOWN" for levels unknown to the client)
+func (l AccessLevel) String() string {
+	if l.IsZero() || l.IsValid() {
+		return string(l)
+	}
+
+	return string(ACCESS_LEVEL_UNKNOWN)
+}
+
+// Raw returns level name as it was received from API
+func (l AccessLevel) Raw() string {
+	return string(l)
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (l AccessLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(l))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (l *AccessLevel) UnmarshalJSON(data []byte) error {
+	var name string
+
+	err := json.Unmarshal(data, &name)
+
+	if err != nil {
+		return fmt.Errorf("Can't decode live stream access level: %w", err)
+	}
+
+	*l = AccessLevel(name)
+
+	return nil
+}
@@ -0,0 +1,349 @@
+// Package lifecycle provides manager for automatic deletion of expired conferences
+package lifecycle
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/internal/clock"
+	"github.com/essentialkaos/telemost/internal/fsutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_INTERVAL is default interval between expiry checks
+const DEFAULT_INTERVAL = time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Clock is source of current time
+type Clock = clock.Clock
+
+// Manager deletes conferences after their expiry time
+type Manager struct {
+	// Clock is source of current time (system clock is used if nil)
+	Clock Clock
+
+	// Interval is interval between expiry checks in Run (DEFAULT_INTERVAL is used
+	// if zero)
+	Interval time.Duration
+
+	// OnError is called if expired conference can't be deleted or schedule
+	// can't be saved. Conferences which weren't deleted are retried on the next
+	// check.
+	OnError func(err error)
+
+	client   *telemost.Client
+	path     string
+	schedule map[string]time.Time
+	mu       sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilClient  = fmt.Errorf("Client is nil")
+	ErrNilManager = fmt.Errorf("Manager is nil")
+	ErrEmptyID    = fmt.Errorf("Conference ID is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// New creates new lifecycle manager. Schedule is persisted to the file with given
+// path and loaded from it if the file exists. If path is empty, schedule is kept
+// only in memory.
+func New(client *telemost.Client, path string) (*Manager, error) {
+	if client == nil {
+		return nil, ErrNilClient
+	}
+
+	m := &Manager{
+		client:   client,
+		path:     path,
+		schedule: make(map[string]time.Time),
+	}
+
+	if path == "" {
+		return m, nil
+	}
+
+	data, err := os.ReadFile(path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return m, nil
+	case err != nil:
+		return nil, fmt.Errorf("Can't read schedule: %w", err)
+	}
+
+	err = json.Unmarshal(data, &m.schedule)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode schedule: %w", err)
+	}
+
+	if m.schedule == nil {
+		m.schedule = make(map[string]time.Time)
+	}
+
+	return m, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Create creates conference which will be deleted after given TTL. If conference
+// can't be scheduled for deletion, it's deleted immediately and error is returned.
+func (m *Manager) Create(ctx context.Context, conf *telemost.Conference, ttl time.Duration) (*telemost.ConferenceInfo, error) {
+	switch {
+	case m == nil || m.client == nil:
+		return nil, ErrNilManager
+	case ttl <= 0:
+		return nil, fmt.Errorf("TTL must be greater than 0 (%s)", ttl)
+	}
+
+	info, err := m.client.CreateContext(ctx, conf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Schedule(info.ID, m.now().Add(ttl))
+
+	if err != nil {
+		delErr := m.client.DeleteContext(context.WithoutCancel(ctx), info.ID)
+
+		if delErr != nil {
+			delErr = fmt.Errorf("Can't delete unscheduled conference %s: %w", info.ID, delErr)
+		}
+
+		return nil, errors.Join(err, delErr)
+	}
+
+	return info, nil
+}
+
+// Schedule sets expiry time for conference with given ID. Schedule isn't changed
+// if it can't be saved.
+func (m *Manager) Schedule(id string, expires time.Time) error {
+	switch {
+	case m == nil || m.client == nil:
+		return ErrNilManager
+	case id == "":
+		return ErrEmptyID
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, exists := m.schedule[id]
+	m.schedule[id] = expires
+
+	err := m.save()
+
+	if err != nil {
+		m.restore(id, prev, exists)
+	}
+
+	return err
+}
+
+// Cancel removes conference with given ID from schedule
+func (m *Manager) Cancel(id string) error {
+	if m == nil || m.client == nil {
+		return ErrNilManager
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, exists := m.schedule[id]
+
+	if !exists {
+		return nil
+	}
+
+	delete(m.schedule, id)
+
+	err := m.save()
+
+	if err != nil {
+		m.restore(id, prev, exists)
+	}
+
+	return err
+}
+
+// Expiry returns expiry time of conference with given ID
+func (m *Manager) Expiry(id string) (time.Time, bool) {
+	if m == nil || m.client == nil {
+		return time.Time{}, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires, ok := m.schedule[id]
+
+	return expires, ok
+}
+
+// Len returns number of scheduled conferences
+func (m *Manager) Len() int {
+	if m == nil || m.client == nil {
+		return 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.schedule)
+}
+
+// Process deletes all expired conferences and returns number of deleted
+// conferences. Conferences which were already deleted are removed from schedule.
+// Conferences rescheduled during processing stay in schedule with new expiry time.
+func (m *Manager) Process(ctx context.Context) (int, error) {
+	if m == nil || m.client == nil {
+		return 0, ErrNilManager
+	}
+
+	now := m.now()
+
+	m.mu.Lock()
+
+	expired := make(map[string]time.Time)
+
+	for id, expires := range m.schedule {
+		if !expires.After(now) {
+			expired[id] = expires
+		}
+	}
+
+	m.mu.Unlock()
+
+	var deleted []string
+
+	for id := range expired {
+		if ctx.Err() != nil {
+			break
+		}
+
+		err := m.client.DeleteContext(ctx, id)
+
+		if err != nil && !errors.Is(err, telemost.ErrNotFound) {
+			m.reportError(fmt.Errorf("Can't delete conference %s: %w", id, err))
+			continue
+		}
+
+		deleted = append(deleted, id)
+	}
+
+	if len(deleted) == 0 {
+		return 0, ctx.Err()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range deleted {
+		if expires, ok := m.schedule[id]; ok && expires.Equal(expired[id]) {
+			delete(m.schedule, id)
+		}
+	}
+
+	return len(deleted), m.save()
+}
+
+// Run checks schedule and deletes expired conferences until context is canceled
+func (m *Manager) Run(ctx context.Context) error {
+	if m == nil || m.client == nil {
+		return ErrNilManager
+	}
+
+	interval := m.Interval
+
+	if interval <= 0 {
+		interval = DEFAULT_INTERVAL
+	}
+
+	for {
+		_, err := m.Process(ctx)
+
+		if err != nil && ctx.Err() == nil {
+			m.reportError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-m.clock().After(interval):
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// clock returns manager clock
+func (m *Manager) clock() Clock {
+	if m.Clock == nil {
+		return clock.System{}
+	}
+
+	return m.Clock
+}
+
+// now returns current time
+func (m *Manager) now() time.Time {
+	return m.clock().Now()
+}
+
+// reportError sends error to error handler
+func (m *Manager) reportError(err error) {
+	if m.OnError != nil {
+		m.OnError(err)
+	}
+}
+
+// restore restores expiry time of conference or removes it from schedule if it
+// wasn't scheduled
+func (m *Manager) restore(id string, expires time.Time, exists bool) {
+	if exists {
+		m.schedule[id] = expires
+	} else {
+		delete(m.schedule, id)
+	}
+}
+
+// save atomically writes schedule to file
+func (m *Manager) save() error {
+	if m.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m.schedule, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("Can't encode schedule: %w", err)
+	}
+
+	err = fsutil.WriteFileAtomic(m.path, data, 0600)
+
+	if err != nil {
+		return fmt.Errorf("Can't save schedule: %w", err)
+	}
+
+	return nil
+}
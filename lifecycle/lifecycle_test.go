@@ -0,0 +1,253 @@
+package lifecycle
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/internal/clock"
+	"github.com/essentialkaos/telemost/telemosttest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type LifecycleSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&LifecycleSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *LifecycleSuite) TestProcess(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+	clock := clock.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	file := c.MkDir() + "/schedule.json"
+
+	_, err := New(nil, file)
+	c.Assert(err, Equals, ErrNilClient)
+
+	m, err := New(api, file)
+	c.Assert(err, IsNil)
+
+	m.Clock = clock
+
+	_, err = m.Create(context.Background(), &telemost.Conference{}, 0)
+	c.Assert(err, ErrorMatches, "TTL must be greater than 0 \\(0s\\)")
+
+	info1, err := m.Create(context.Background(), &telemost.Conference{}, time.Hour)
+	c.Assert(err, IsNil)
+	info2, err := m.Create(context.Background(), &telemost.Conference{}, 3*time.Hour)
+	c.Assert(err, IsNil)
+	info3, err := m.Create(context.Background(), &telemost.Conference{}, time.Hour)
+	c.Assert(err, IsNil)
+
+	expires, ok := m.Expiry(info1.ID)
+	c.Assert(ok, Equals, true)
+	c.Assert(expires.Equal(clock.Now().Add(time.Hour)), Equals, true)
+	c.Assert(m.Len(), Equals, 3)
+
+	c.Assert(m.Cancel(info3.ID), IsNil)
+	c.Assert(m.Cancel(info3.ID), IsNil)
+	c.Assert(m.Schedule("", clock.Now()), Equals, ErrEmptyID)
+
+	n, err := m.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+
+	clock.Advance(time.Hour)
+
+	n, err = m.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+
+	_, ok = srv.Conference(info1.ID)
+	c.Assert(ok, Equals, false)
+	_, ok = srv.Conference(info3.ID)
+	c.Assert(ok, Equals, true)
+
+	// Schedule survives restart
+	m, err = New(api, file)
+	c.Assert(err, IsNil)
+
+	m.Clock = clock
+
+	c.Assert(m.Len(), Equals, 1)
+
+	var errs []error
+
+	m.OnError = func(err error) { errs = append(errs, err) }
+
+	clock.Advance(2 * time.Hour)
+	srv.FailNext(1, 403)
+
+	n, err = m.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errors.Is(errs[0], telemost.ErrForbidden), Equals, true)
+	c.Assert(m.Len(), Equals, 1)
+
+	n, err = m.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+	c.Assert(m.Len(), Equals, 0)
+
+	_, ok = srv.Conference(info2.ID)
+	c.Assert(ok, Equals, false)
+
+	// Conferences deleted outside of manager are removed from schedule
+	c.Assert(m.Schedule(info3.ID, clock.Now()), IsNil)
+	c.Assert(api.Delete(info3.ID), IsNil)
+
+	n, err = m.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+	c.Assert(m.Len(), Equals, 0)
+
+	// Conferences rescheduled during processing stay in schedule
+	info4, err := m.Create(context.Background(), &telemost.Conference{}, time.Hour)
+	c.Assert(err, IsNil)
+
+	api.Use(func(next telemost.Handler) telemost.Handler {
+		return func(ctx context.Context, r *telemost.Request) error {
+			if r.Operation == telemost.OP_DELETE {
+				m.Schedule(r.ID, clock.Now().Add(time.Hour))
+			}
+
+			return next(ctx, r)
+		}
+	})
+
+	clock.Advance(time.Hour)
+
+	n, err = m.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+
+	expires, ok = m.Expiry(info4.ID)
+	c.Assert(ok, Equals, true)
+	c.Assert(expires.Equal(clock.Now().Add(time.Hour)), Equals, true)
+}
+
+func (s *LifecycleSuite) TestRun(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+	clock := clock.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	m, err := New(api, "")
+	c.Assert(err, IsNil)
+
+	m.Clock = clock
+	m.Interval = 10 * time.Minute
+
+	info, err := m.Create(context.Background(), &telemost.Conference{}, 15*time.Minute)
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- m.Run(ctx) }()
+
+	for range 100 {
+		if m.Len() == 0 {
+			break
+		}
+
+		clock.Advance(10 * time.Minute)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	c.Assert(m.Len(), Equals, 0)
+
+	_, ok := srv.Conference(info.ID)
+	c.Assert(ok, Equals, false)
+
+	cancel()
+
+	c.Assert(<-done, Equals, context.Canceled)
+}
+
+func (s *LifecycleSuite) TestErrors(c *C) {
+	api, _ := telemost.NewClient("Test1234")
+	dir := c.MkDir()
+
+	c.Assert(os.WriteFile(dir+"/schedule.json", []byte("{"), 0600), IsNil)
+
+	_, err := New(api, dir+"/schedule.json")
+	c.Assert(err, ErrorMatches, "Can't decode schedule: .*")
+
+	_, err = New(api, dir)
+	c.Assert(err, ErrorMatches, "Can't read schedule: .*")
+
+	m, _ := New(api, dir+"/unknown/schedule.json")
+	c.Assert(m.Schedule("12345678901234", time.Now()), ErrorMatches, "Can't save schedule: .*")
+	c.Assert(m.Len(), Equals, 0)
+
+	// Conference is deleted if it can't be scheduled
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	api, _ = telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+	m, _ = New(api, dir+"/unknown/schedule.json")
+
+	info, err := m.Create(context.Background(), &telemost.Conference{}, time.Hour)
+	c.Assert(err, ErrorMatches, "Can't save schedule: .*")
+	c.Assert(info, IsNil)
+	c.Assert(srv.Conferences(), HasLen, 0)
+	c.Assert(m.Len(), Equals, 0)
+
+	api.Use(func(next telemost.Handler) telemost.Handler {
+		return func(ctx context.Context, r *telemost.Request) error {
+			if r.Operation == telemost.OP_DELETE {
+				return telemost.ErrForbidden
+			}
+
+			return next(ctx, r)
+		}
+	})
+
+	_, err = m.Create(context.Background(), &telemost.Conference{}, time.Hour)
+	c.Assert(err, ErrorMatches, "(?s)Can't save schedule: .*\\nCan't delete unscheduled conference .*: Forbidden")
+	c.Assert(errors.Is(err, telemost.ErrForbidden), Equals, true)
+
+	// Canceling isn't applied if schedule can't be saved
+	m, _ = New(api, dir+"/schedule2.json")
+	c.Assert(m.Schedule("12345678901234", time.Now()), IsNil)
+	m.path = dir + "/unknown/schedule.json"
+	c.Assert(m.Cancel("12345678901234"), ErrorMatches, "Can't save schedule: .*")
+	c.Assert(m.Len(), Equals, 1)
+
+	var nilManager *Manager
+
+	_, err = nilManager.Create(context.Background(), nil, time.Hour)
+	c.Assert(err, Equals, ErrNilManager)
+	c.Assert(nilManager.Schedule("1", time.Now()), Equals, ErrNilManager)
+	c.Assert(nilManager.Cancel("1"), Equals, ErrNilManager)
+	_, err = nilManager.Process(context.Background())
+	c.Assert(err, Equals, ErrNilManager)
+	c.Assert(nilManager.Run(context.Background()), Equals, ErrNilManager)
+	c.Assert(nilManager.Len(), Equals, 0)
+
+	_, ok := nilManager.Expiry("1")
+	c.Assert(ok, Equals, false)
+}
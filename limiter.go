@@ -0,0 +1,135 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Limiter is token bucket rate limiter safe for concurrent use. One limiter can be
+// shared between several clients.
+type Limiter struct {
+	mu sync.Mutex
+
+	rate   float64   // Tokens per second
+	burst  float64   // Bucket size
+	tokens float64   // Available tokens
+	last   time.Time // Time of the last bucket update
+
+	stats LimiterStats
+}
+
+// LimiterStats contains limiter usage statistics
+type LimiterStats struct {
+	Requests  int64         // Total number of requests
+	Throttled int64         // Number of requests which were delayed
+	WaitTime  time.Duration // Total time spent waiting
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewLimiter creates new limiter with given rate (requests per second) and burst
+// size. If rps is less than or equal to 0, it returns nil.
+func NewLimiter(rps float64, burst int) *Limiter {
+	if rps <= 0 {
+		return nil
+	}
+
+	burst = max(burst, 1)
+
+	return &Limiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Wait blocks until request can be sent or context is done
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay := l.reserve()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	start := time.Now()
+
+	select {
+	case <-ctx.Done():
+		l.cancel(time.Since(start))
+		return ctx.Err()
+	case <-timer.C:
+		l.record(delay)
+	}
+
+	return nil
+}
+
+// Stats returns limiter usage statistics
+func (l *Limiter) Stats() LimiterStats {
+	if l == nil {
+		return LimiterStats{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stats
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// reserve takes token from bucket and returns delay before it become available
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	l.stats.Requests++
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	l.stats.Throttled++
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// record adds waiting time to statistics
+func (l *Limiter) record(wait time.Duration) {
+	l.mu.Lock()
+	l.stats.WaitTime += wait
+	l.mu.Unlock()
+}
+
+// cancel returns reserved token to bucket
+func (l *Limiter) cancel(wait time.Duration) {
+	l.mu.Lock()
+	l.tokens = min(l.burst, l.tokens+1)
+	l.stats.WaitTime += wait
+	l.mu.Unlock()
+}
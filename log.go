@@ -0,0 +1,137 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// REDACTED is placeholder used instead of sensitive data in logs
+const REDACTED = "[REDACTED]"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// emailRegex is regex for matching local part of email
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@([A-Za-z0-9\-]+\.)`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// logAttempt logs result of request attempt
+func (c *Client) logAttempt(ctx context.Context, method, endpoint string, attempt, statusCode int, latency time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.Int("status", statusCode),
+		slog.Duration("latency", latency),
+		slog.Int("attempt", attempt),
+	}
+
+	if err == nil {
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "API request completed", attrs...)
+		return
+	}
+
+	apiErr := &APIError{}
+
+	if errors.As(err, &apiErr) {
+		attrs = append(attrs,
+			slog.String("error_code", apiErr.Code),
+			slog.String("request_id", apiErr.RequestID),
+		)
+	}
+
+	attrs = append(attrs, slog.String("error", redactEmails(err.Error())))
+
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "API request failed", attrs...)
+}
+
+// logRequest dumps request to debug log
+func (c *Client) logRequest(ctx context.Context, r *http.Request, data []byte) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	reqURL, err := url.QueryUnescape(r.URL.String())
+
+	if err != nil {
+		reqURL = r.URL.String()
+	}
+
+	c.logger.LogAttrs(
+		ctx, slog.LevelDebug, "API request",
+		slog.String("method", r.Method),
+		slog.String("url", redactEmails(reqURL)),
+		slog.Any("headers", redactHeaders(r.Header)),
+		slog.String("body", redactEmails(string(data))),
+	)
+}
+
+// logResponse dumps response to debug log
+func (c *Client) logResponse(ctx context.Context, resp *http.Response) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	data, err := io.ReadAll(resp.Body)
+
+	// Restore body so it can be decoded later
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	if err != nil {
+		c.logger.LogAttrs(
+			ctx, slog.LevelDebug, "Can't read API response body",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	c.logger.LogAttrs(
+		ctx, slog.LevelDebug, "API response",
+		slog.Int("status", resp.StatusCode),
+		slog.Any("headers", redactHeaders(resp.Header)),
+		slog.String("body", redactEmails(string(data))),
+	)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// redactHeaders returns copy of headers with redacted credentials
+func redactHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+
+	for name, values := range header {
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Cookie", "Set-Cookie":
+			result[name] = REDACTED
+		default:
+			result[name] = strings.Join(values, ", ")
+		}
+	}
+
+	return result
+}
+
+// redactEmails replaces local part of all emails in given string
+func redactEmails(data string) string {
+	return emailRegex.ReplaceAllString(data, REDACTED+"@$1")
+}
@@ -0,0 +1,31 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Metrics is interface for collectors of client metrics
+//
+// Collector is updated by every goroutine sending requests through client, and
+// can be shared between several clients. Ready-to-use Prometheus collector is
+// available in telemostprom package.
+type Metrics interface {
+	// ObserveRequest is called after every request to API. Status code is 0 if
+	// response wasn't received.
+	ObserveRequest(op string, statusCode int, latency time.Duration, err error)
+
+	// ObserveRetry is called before retrying failed request
+	ObserveRetry(op string)
+
+	// ObserveRateLimitWait is called after waiting for client-side rate limiter
+	ObserveRateLimitWait(op string, wait time.Duration)
+}
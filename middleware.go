@@ -0,0 +1,72 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"net/url"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Request contains info about request to API
+type Request struct {
+	Operation string     // Client operation name (OP_*)
+	Method    string     // HTTP method
+	Endpoint  string     // Endpoint path relative to API base URL
+	ID        string     // Conference ID (empty for conference creation)
+	Query     url.Values // Query parameters (multiple values are sent comma-separated)
+	Payload   any        // Request payload encoded to JSON
+	Response  any        // Pointer to value used for decoding response
+}
+
+// Handler sends request to API
+type Handler func(ctx context.Context, r *Request) error
+
+// Middleware wraps handler with additional logic
+//
+// Middleware can modify request before calling next handler, inspect decoded
+// response or error after it, or return without calling next handler at all.
+type Middleware func(next Handler) Handler
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Use adds middlewares to client. Middlewares are called in the order they were
+// added, so the first one sees the request first and the response last. Cache
+// enabled with WithCache always goes first, so requests served from cache don't
+// reach middlewares.
+func (c *Client) Use(mw ...Middleware) {
+	if c == nil || c.engine == nil {
+		return
+	}
+
+	c.mwMu.Lock()
+	defer c.mwMu.Unlock()
+
+	for _, m := range mw {
+		if m != nil {
+			c.middlewares = append(c.middlewares, m)
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// chain returns handler wrapped by all client middlewares
+func (c *Client) chain() Handler {
+	c.mwMu.RLock()
+	defer c.mwMu.RUnlock()
+
+	h := Handler(c.send)
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+
+	return h
+}
@@ -0,0 +1,306 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/req"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Option is client configuration option
+type Option func(cfg *config) error
+
+// config contains client configuration
+type config struct {
+	baseURL    string
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    time.Duration
+	proxy      *url.URL
+	tlsConfig  *tls.Config
+	retry      RetryPolicy
+	limiter    *Limiter
+	pageSize   int
+	logger     *slog.Logger
+	tracer     Tracer
+	metrics    Metrics
+	cache      *Cache
+	sip        SIPResolver
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrEmptyBaseURL     = fmt.Errorf("Base URL is empty")
+	ErrNilHTTPClient    = fmt.Errorf("HTTP client is nil")
+	ErrNilTransport     = fmt.Errorf("Transport is nil")
+	ErrNilProxy         = fmt.Errorf("Proxy URL is nil")
+	ErrNilTLSConfig     = fmt.Errorf("TLS config is nil")
+	ErrNilLimiter       = fmt.Errorf("Limiter is nil")
+	ErrNilLogger        = fmt.Errorf("Logger is nil")
+	ErrNilTracer        = fmt.Errorf("Tracer is nil")
+	ErrNilMetrics       = fmt.Errorf("Metrics collector is nil")
+	ErrNilCache         = fmt.Errorf("Cache is nil")
+	ErrNilSIPResolver   = fmt.Errorf("SIP resolver is nil")
+	ErrIncompatibleOpts = fmt.Errorf("Proxy and TLS options can't be used with custom HTTP client or transport")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WithBaseURL sets API base URL used by client instead of global API variable
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *config) error {
+		switch {
+		case baseURL == "":
+			return ErrEmptyBaseURL
+		case !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://"):
+			return fmt.Errorf("Unsupported scheme in base URL %q", baseURL)
+		}
+
+		cfg.baseURL = strings.TrimRight(baseURL, "/")
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets custom HTTP client used for sending requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) error {
+		if client == nil {
+			return ErrNilHTTPClient
+		}
+
+		cfg.httpClient = client
+
+		return nil
+	}
+}
+
+// WithTransport sets custom round tripper used for sending requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *config) error {
+		if transport == nil {
+			return ErrNilTransport
+		}
+
+		cfg.transport = transport
+
+		return nil
+	}
+}
+
+// WithTimeout sets request timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) error {
+		if timeout < 0 {
+			return fmt.Errorf("Timeout can't be negative (%s)", timeout)
+		}
+
+		cfg.timeout = timeout
+
+		return nil
+	}
+}
+
+// WithProxy sets proxy used for sending requests
+func WithProxy(proxy *url.URL) Option {
+	return func(cfg *config) error {
+		if proxy == nil {
+			return ErrNilProxy
+		}
+
+		cfg.proxy = proxy
+
+		return nil
+	}
+}
+
+// WithTLSConfig sets TLS configuration used for sending requests
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(cfg *config) error {
+		if tlsConfig == nil {
+			return ErrNilTLSConfig
+		}
+
+		cfg.tlsConfig = tlsConfig
+
+		return nil
+	}
+}
+
+// WithRetryPolicy sets policy for retrying failed requests
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cfg *config) error {
+		err := policy.Validate()
+
+		if err != nil {
+			return err
+		}
+
+		cfg.retry = policy
+
+		return nil
+	}
+}
+
+// WithLimiter sets rate limiter used before sending every request
+func WithLimiter(limiter *Limiter) Option {
+	return func(cfg *config) error {
+		if limiter == nil {
+			return ErrNilLimiter
+		}
+
+		cfg.limiter = limiter
+
+		return nil
+	}
+}
+
+// WithPageSize sets number of items fetched per request by paginating methods
+func WithPageSize(size int) Option {
+	return func(cfg *config) error {
+		if size < 1 {
+			return fmt.Errorf("Page size must be greater than 0 (%d)", size)
+		}
+
+		cfg.pageSize = size
+
+		return nil
+	}
+}
+
+// WithLogger sets logger used for logging API requests
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) error {
+		if logger == nil {
+			return ErrNilLogger
+		}
+
+		cfg.logger = logger
+
+		return nil
+	}
+}
+
+// WithTracer sets tracer of client operations and API requests
+func WithTracer(tracer Tracer) Option {
+	return func(cfg *config) error {
+		if tracer == nil {
+			return ErrNilTracer
+		}
+
+		cfg.tracer = tracer
+
+		return nil
+	}
+}
+
+// WithMetrics sets collector for client metrics
+func WithMetrics(metrics Metrics) Option {
+	return func(cfg *config) error {
+		if metrics == nil {
+			return ErrNilMetrics
+		}
+
+		cfg.metrics = metrics
+
+		return nil
+	}
+}
+
+// WithCache enables caching of Get and GetCohosts responses
+//
+// Cache works as the outermost middleware, so middlewares added with Use are
+// called only for requests sent to API and don't see responses served from cache.
+func WithCache(cache *Cache) Option {
+	return func(cfg *config) error {
+		if cache == nil {
+			return ErrNilCache
+		}
+
+		cfg.cache = cache
+
+		return nil
+	}
+}
+
+// WithSIPResolver sets resolver used for finding conferences by SIP URI or SIP ID
+func WithSIPResolver(resolver SIPResolver) Option {
+	return func(cfg *config) error {
+		if resolver == nil {
+			return ErrNilSIPResolver
+		}
+
+		cfg.sip = resolver
+
+		return nil
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// apply applies given options to configuration
+func (cfg *config) apply(opts []Option) error {
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		err := opt(cfg)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if (cfg.proxy != nil || cfg.tlsConfig != nil) &&
+		(cfg.httpClient != nil || cfg.transport != nil) {
+		return ErrIncompatibleOpts
+	}
+
+	return nil
+}
+
+// engine creates request engine based on configuration
+func (cfg *config) engine() *req.Engine {
+	e := &req.Engine{}
+
+	if cfg.httpClient != nil {
+		hc := *cfg.httpClient
+		e.Client = &hc
+	}
+
+	e.Init()
+
+	if cfg.transport != nil {
+		e.Client.Transport = cfg.transport
+	}
+
+	if cfg.timeout > 0 {
+		e.Client.Timeout = cfg.timeout
+	}
+
+	if cfg.proxy != nil {
+		e.Transport.Proxy = http.ProxyURL(cfg.proxy)
+	}
+
+	if cfg.tlsConfig != nil {
+		e.Transport.TLSClientConfig = cfg.tlsConfig
+	}
+
+	return e
+}
@@ -0,0 +1,259 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/essentialkaos/ek/v13/req"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ConferencePatch is builder for partial conference update. Fields which are not
+// set are omitted from request, cleared fields are sent as null.
+type ConferencePatch struct {
+	waitingRoomLevel patchField[WaitingRoomLevel]
+	liveStream       patchField[struct{}]
+	accessLevel      patchField[AccessLevel]
+	title            patchField[string]
+	description      patchField[string]
+	cohosts          patchField[Hosts]
+}
+
+// patchField contains state of single patch field
+type patchField[T any] struct {
+	value T
+	state uint8
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	fieldUnset uint8 = iota
+	fieldSet
+	fieldClear
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilPatch   = fmt.Errorf("Patch is nil")
+	ErrEmptyPatch = fmt.Errorf("Patch is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewPatch creates new empty conference patch
+func NewPatch() *ConferencePatch {
+	return &ConferencePatch{}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetWaitingRoomLevel sets waiting room level
+func (p *ConferencePatch) SetWaitingRoomLevel(level WaitingRoomLevel) *ConferencePatch {
+	p.waitingRoomLevel = patchField[WaitingRoomLevel]{level, fieldSet}
+	return p
+}
+
+// ClearWaitingRoomLevel resets waiting room level to default
+func (p *ConferencePatch) ClearWaitingRoomLevel() *ConferencePatch {
+	p.waitingRoomLevel = patchField[WaitingRoomLevel]{state: fieldClear}
+	return p
+}
+
+// ClearLiveStream removes live stream from conference
+func (p *ConferencePatch) ClearLiveStream() *ConferencePatch {
+	p.liveStream = patchField[struct{}]{state: fieldClear}
+	return p
+}
+
+// SetAccessLevel sets live stream access level
+func (p *ConferencePatch) SetAccessLevel(level AccessLevel) *ConferencePatch {
+	p.accessLevel = patchField[AccessLevel]{level, fieldSet}
+	return p
+}
+
+// ClearAccessLevel resets live stream access level to default
+func (p *ConferencePatch) ClearAccessLevel() *ConferencePatch {
+	p.accessLevel = patchField[AccessLevel]{state: fieldClear}
+	return p
+}
+
+// SetTitle sets live stream title
+func (p *ConferencePatch) SetTitle(title string) *ConferencePatch {
+	p.title = patchField[string]{title, fieldSet}
+	return p
+}
+
+// ClearTitle removes live stream title
+func (p *ConferencePatch) ClearTitle() *ConferencePatch {
+	p.title = patchField[string]{state: fieldClear}
+	return p
+}
+
+// SetDescription sets live stream description
+func (p *ConferencePatch) SetDescription(description string) *ConferencePatch {
+	p.description = patchField[string]{description, fieldSet}
+	return p
+}
+
+// ClearDescription removes live stream description
+func (p *ConferencePatch) ClearDescription() *ConferencePatch {
+	p.description = patchField[string]{state: fieldClear}
+	return p
+}
+
+// SetCohosts replaces conference cohosts. Setting empty list of cohosts fails
+// validation, use ClearCohosts to remove all cohosts.
+func (p *ConferencePatch) SetCohosts(emails ...string) *ConferencePatch {
+	p.cohosts = patchField[Hosts]{convertHosts(emails), fieldSet}
+	return p
+}
+
+// ClearCohosts removes all conference cohosts
+func (p *ConferencePatch) ClearCohosts() *ConferencePatch {
+	p.cohosts = patchField[Hosts]{state: fieldClear}
+	return p
+}
+
+// IsEmpty returns true if patch doesn't contain any changes
+func (p *ConferencePatch) IsEmpty() bool {
+	return p == nil || (p.waitingRoomLevel.state == fieldUnset &&
+		p.liveStream.state == fieldUnset &&
+		p.accessLevel.state == fieldUnset &&
+		p.title.state == fieldUnset &&
+		p.description.state == fieldUnset &&
+		p.cohosts.state == fieldUnset)
+}
+
+// Validate validates patch using the same rules as for conference
+func (p *ConferencePatch) Validate() error {
+	switch {
+	case p == nil:
+		return ErrNilPatch
+	case p.IsEmpty():
+		return ErrEmptyPatch
+	case p.waitingRoomLevel.state == fieldSet && !p.waitingRoomLevel.value.isAllowed():
+		return fmt.Errorf("Unknown waiting room level %q", p.waitingRoomLevel.value.Raw())
+	case p.accessLevel.state == fieldSet && !p.accessLevel.value.isAllowed():
+		return fmt.Errorf("Unknown live stream access level %q", p.accessLevel.value.Raw())
+	case p.cohosts.state == fieldSet && len(p.cohosts.value) == 0:
+		return fmt.Errorf("%w (use ClearCohosts to remove all cohosts)", ErrEmptyCohosts)
+	case p.liveStream.state == fieldClear && p.hasLiveStreamFields():
+		return fmt.Errorf("Live stream can't be cleared and modified at the same time")
+	}
+
+	return validateConference(&Conference{
+		WaitingRoomLevel: p.waitingRoomLevel.value,
+		LiveStream: &LiveStream{
+			AccessLevel: p.accessLevel.value,
+			Title:       p.title.value,
+			Description: p.description.value,
+		},
+		CoHosts: p.cohosts.value,
+	})
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (p *ConferencePatch) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+
+	fields := map[string]any{}
+
+	addPatchField(fields, "waiting_room_level", p.waitingRoomLevel)
+	addPatchField(fields, "cohosts", p.cohosts)
+
+	switch {
+	case p.liveStream.state == fieldClear:
+		fields["live_stream"] = nil
+	case p.hasLiveStreamFields():
+		stream := map[string]any{}
+
+		addPatchField(stream, "access_level", p.accessLevel)
+		addPatchField(stream, "title", p.title)
+		addPatchField(stream, "description", p.description)
+
+		fields["live_stream"] = stream
+	}
+
+	return json.Marshal(fields)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Patch partially updates conference or broadcast
+//
+// https://yandex.ru/dev/telemost/doc/ru/conference-update
+func (c *Client) Patch(id string, patch *ConferencePatch) (*ConferenceInfo, error) {
+	return c.PatchContext(context.Background(), id, patch)
+}
+
+// PatchContext partially updates conference or broadcast using given context
+func (c *Client) PatchContext(ctx context.Context, id string, patch *ConferencePatch) (*ConferenceInfo, error) {
+	switch {
+	case c == nil || c.engine == nil:
+		return nil, ErrNilClient
+	}
+
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = patch.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := c.startSpan(ctx, OP_UPDATE, id, len(patch.cohosts.value))
+
+	info := &ConferenceInfo{}
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_UPDATE,
+		Method:    req.PATCH,
+		Endpoint:  "/" + id,
+		ID:        id,
+		Payload:   patch,
+		Response:  info,
+	})
+
+	span.End(0, err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// hasLiveStreamFields returns true if patch modifies any live stream field
+func (p *ConferencePatch) hasLiveStreamFields() bool {
+	return p.accessLevel.state != fieldUnset ||
+		p.title.state != fieldUnset ||
+		p.description.state != fieldUnset
+}
+
+// addPatchField adds field to map if it was set or cleared
+func addPatchField[T any](fields map[string]any, name string, field patchField[T]) {
+	switch field.state {
+	case fieldSet:
+		fields[name] = field.value
+	case fieldClear:
+		fields[name] = nil
+	}
+}
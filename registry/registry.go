@@ -0,0 +1,412 @@
+// Package registry provides local registry of conferences managed by client
+package registry
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/telemost"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Store is storage for registry records
+type Store interface {
+	// Get returns record with given ID or ErrNotFound
+	Get(id string) (*Record, error)
+
+	// Put adds or replaces record
+	Put(rec *Record) error
+
+	// Remove removes record with given ID
+	Remove(id string) error
+
+	// List returns all records
+	List() ([]*Record, error)
+}
+
+// Registry records conferences created, updated and deleted through client
+type Registry struct {
+	// OnError is called if record can't be saved to store. Store errors don't
+	// affect result of API request.
+	OnError func(err error)
+
+	store Store
+	now   func() time.Time
+	mu    sync.Mutex
+}
+
+// Record contains info about conference
+type Record struct {
+	ID      string                   `json:"id"`
+	Info    *telemost.ConferenceInfo `json:"info,omitempty"`
+	Cohosts []string                 `json:"cohosts,omitempty"`
+	Labels  []string                 `json:"labels,omitempty"`
+	Created time.Time                `json:"created"` // Time when conference was recorded for the first time
+	Updated time.Time                `json:"updated"`
+	Deleted time.Time                `json:"deleted,omitzero"`
+}
+
+// Query contains record search criteria. Empty fields are ignored.
+type Query struct {
+	Cohost        string    // Cohost email
+	Label         string    // Record label
+	CreatedAfter  time.Time // Minimal creation time (inclusive)
+	CreatedBefore time.Time // Maximum creation time (exclusive)
+	WithDeleted   bool      // Include deleted conferences
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilStore    = fmt.Errorf("Store is nil")
+	ErrNilRegistry = fmt.Errorf("Registry is nil")
+	ErrEmptyID     = fmt.Errorf("Conference ID is empty")
+	ErrNotFound    = fmt.Errorf("Record not found")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// labelsKey is context key for record labels
+type labelsKey struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// New creates new registry with given store
+func New(store Store) (*Registry, error) {
+	if store == nil {
+		return nil, ErrNilStore
+	}
+
+	return &Registry{store: store, now: time.Now}, nil
+}
+
+// WithLabels returns context with labels which will be added to records of
+// conferences created or updated using this context
+func WithLabels(ctx context.Context, labels ...string) context.Context {
+	return context.WithValue(ctx, labelsKey{}, append(getLabels(ctx), labels...))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Middleware is client middleware which records results of successful requests.
+// Add it to client using telemost.Client.Use method.
+func (r *Registry) Middleware(next telemost.Handler) telemost.Handler {
+	return func(ctx context.Context, req *telemost.Request) error {
+		err := next(ctx, req)
+
+		if err != nil || r == nil || r.store == nil {
+			return err
+		}
+
+		err = r.record(ctx, req)
+
+		if err != nil && r.OnError != nil {
+			r.OnError(err)
+		}
+
+		return nil
+	}
+}
+
+// Get returns record with given ID
+func (r *Registry) Get(id string) (*Record, error) {
+	switch {
+	case r == nil || r.store == nil:
+		return nil, ErrNilRegistry
+	case id == "":
+		return nil, ErrEmptyID
+	}
+
+	return r.store.Get(id)
+}
+
+// SetLabels replaces labels of record with given ID
+func (r *Registry) SetLabels(id string, labels ...string) error {
+	switch {
+	case r == nil || r.store == nil:
+		return ErrNilRegistry
+	case id == "":
+		return ErrEmptyID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, err := r.store.Get(id)
+
+	if err != nil {
+		return err
+	}
+
+	rec.Labels = normalizeLabels(labels)
+	rec.Updated = r.now()
+
+	return r.store.Put(rec)
+}
+
+// Find returns records matching given query sorted by creation time
+func (r *Registry) Find(q Query) ([]*Record, error) {
+	if r == nil || r.store == nil {
+		return nil, ErrNilRegistry
+	}
+
+	records, err := r.store.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	records = slices.DeleteFunc(records, func(rec *Record) bool {
+		return !q.isMatch(rec)
+	})
+
+	slices.SortFunc(records, func(a, b *Record) int {
+		return cmp.Or(a.Created.Compare(b.Created), strings.Compare(a.ID, b.ID))
+	})
+
+	return records, nil
+}
+
+// FindByCohost returns active conferences with given cohost
+func (r *Registry) FindByCohost(email string) ([]*Record, error) {
+	return r.Find(Query{Cohost: email})
+}
+
+// FindByLabel returns active conferences with given label
+func (r *Registry) FindByLabel(label string) ([]*Record, error) {
+	return r.Find(Query{Label: label})
+}
+
+// FindCreated returns active conferences created in given time range. Zero time
+// means that range is not limited from this side.
+func (r *Registry) FindCreated(after, before time.Time) ([]*Record, error) {
+	return r.Find(Query{CreatedAfter: after, CreatedBefore: before})
+}
+
+// ResolveSIP returns ID of active conference with given SIP URI (without "sip:"
+// scheme) or SIP ID. It implements telemost.SIPResolver interface.
+func (r *Registry) ResolveSIP(ctx context.Context, sip string) (string, error) {
+	if sip == "" {
+		return "", telemost.ErrUnknownSIP
+	}
+
+	records, err := r.Find(Query{})
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, rec := range records {
+		if rec.Info == nil {
+			continue
+		}
+
+		if rec.Info.SIPID == sip || strings.EqualFold(strings.TrimPrefix(rec.Info.SIPURIMeeting, "sip:"), sip) {
+			return rec.ID, nil
+		}
+	}
+
+	return "", telemost.ErrUnknownSIP
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsDeleted returns true if conference was deleted
+func (r *Record) IsDeleted() bool {
+	return r != nil && !r.Deleted.IsZero()
+}
+
+// HasCohost returns true if conference has cohost with given email
+func (r *Record) HasCohost(email string) bool {
+	return r != nil && slices.ContainsFunc(r.Cohosts, func(e string) bool {
+		return strings.EqualFold(e, email)
+	})
+}
+
+// HasLabel returns true if record has given label
+func (r *Record) HasLabel(label string) bool {
+	return r != nil && slices.Contains(r.Labels, label)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// record updates record using request data
+func (r *Registry) record(ctx context.Context, req *telemost.Request) error {
+	switch req.Operation {
+	case telemost.OP_CREATE, telemost.OP_UPDATE, telemost.OP_DELETE,
+		telemost.OP_ADD_COHOSTS, telemost.OP_UPDATE_COHOSTS, telemost.OP_DELETE_COHOSTS:
+		// continue
+	default:
+		return nil
+	}
+
+	info, _ := req.Response.(*telemost.ConferenceInfo)
+	id := req.ID
+
+	if req.Operation == telemost.OP_CREATE && info != nil {
+		id = info.ID
+	}
+
+	if id == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	rec, err := r.store.Get(id)
+
+	switch {
+	case errors.Is(err, ErrNotFound) && req.Operation == telemost.OP_CREATE,
+		errors.Is(err, ErrNotFound) && req.Operation == telemost.OP_UPDATE:
+		rec = &Record{ID: id, Created: now}
+	case errors.Is(err, ErrNotFound):
+		// We don't know full state of conference, so there is nothing to update
+		return nil
+	case err != nil:
+		return fmt.Errorf("Can't read record %s: %w", id, err)
+	}
+
+	switch req.Operation {
+	case telemost.OP_CREATE, telemost.OP_UPDATE:
+		if info != nil && info.ID != "" {
+			rec.Info = info
+		}
+
+		if cohosts, ok := extractCohosts(req.Payload); ok {
+			rec.Cohosts = cohosts
+		}
+
+		rec.Labels = normalizeLabels(append(rec.Labels, getLabels(ctx)...))
+
+	case telemost.OP_DELETE:
+		rec.Deleted = now
+
+	case telemost.OP_ADD_COHOSTS:
+		cohosts, _ := extractCohosts(req.Payload)
+		rec.Cohosts = mergeEmails(rec.Cohosts, cohosts)
+
+	case telemost.OP_UPDATE_COHOSTS:
+		rec.Cohosts, _ = extractCohosts(req.Payload)
+
+	case telemost.OP_DELETE_COHOSTS:
+		rec.Cohosts = removeEmails(rec.Cohosts, req.Query["cohost_emails"])
+	}
+
+	rec.Updated = now
+
+	err = r.store.Put(rec)
+
+	if err != nil {
+		return fmt.Errorf("Can't save record %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// isMatch returns true if record matches query
+func (q Query) isMatch(rec *Record) bool {
+	switch {
+	case rec == nil,
+		!q.WithDeleted && rec.IsDeleted(),
+		q.Cohost != "" && !rec.HasCohost(q.Cohost),
+		q.Label != "" && !rec.HasLabel(q.Label),
+		!q.CreatedAfter.IsZero() && rec.Created.Before(q.CreatedAfter),
+		!q.CreatedBefore.IsZero() && !rec.Created.Before(q.CreatedBefore):
+		return false
+	}
+
+	return true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getLabels returns labels from context
+func getLabels(ctx context.Context) []string {
+	labels, _ := ctx.Value(labelsKey{}).([]string)
+	return slices.Clip(labels)
+}
+
+// extractCohosts extracts cohosts emails from request payload. It returns false
+// if payload doesn't contain cohosts.
+func extractCohosts(payload any) ([]string, bool) {
+	if payload == nil {
+		return nil, false
+	}
+
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, false
+	}
+
+	var fields map[string]json.RawMessage
+
+	if json.Unmarshal(data, &fields) != nil {
+		return nil, false
+	}
+
+	raw, ok := fields["cohosts"]
+
+	if !ok {
+		return nil, false
+	}
+
+	var cohosts telemost.Hosts
+
+	if json.Unmarshal(raw, &cohosts) != nil {
+		return nil, false
+	}
+
+	// Cohosts list is null if cohosts were cleared by patch
+	return cohosts.Flatten(), true
+}
+
+// mergeEmails appends new emails to the list
+func mergeEmails(current, emails []string) []string {
+	for _, email := range emails {
+		if !slices.ContainsFunc(current, func(e string) bool { return strings.EqualFold(e, email) }) {
+			current = append(current, email)
+		}
+	}
+
+	return current
+}
+
+// removeEmails removes given emails from the list
+func removeEmails(current, emails []string) []string {
+	return slices.DeleteFunc(current, func(e string) bool {
+		return slices.ContainsFunc(emails, func(email string) bool {
+			return strings.EqualFold(e, email)
+		})
+	})
+}
+
+// normalizeLabels returns sorted list of unique non-empty labels
+func normalizeLabels(labels []string) []string {
+	labels = slices.DeleteFunc(slices.Clone(labels), func(l string) bool { return l == "" })
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	slices.Sort(labels)
+
+	return slices.Compact(labels)
+}
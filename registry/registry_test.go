@@ -0,0 +1,329 @@
+package registry
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/telemosttest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type RegistrySuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&RegistrySuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *RegistrySuite) TestRegistry(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	_, err := New(nil)
+	c.Assert(err, Equals, ErrNilStore)
+
+	reg, err := New(NewMemoryStore())
+	c.Assert(err, IsNil)
+
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	reg.now = func() time.Time { return ts }
+
+	api, err := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+	c.Assert(err, IsNil)
+
+	api.Use(reg.Middleware)
+
+	ctx := WithLabels(context.Background(), "team-a")
+	ctx = WithLabels(ctx, "weekly", "")
+
+	info1, err := api.CreateContext(ctx, (&telemost.Conference{}).WithCohosts("user1@yandex.ru"))
+	c.Assert(err, IsNil)
+
+	rec, err := reg.Get(info1.ID)
+	c.Assert(err, IsNil)
+	c.Assert(rec.Info.JoinURL, Equals, info1.JoinURL)
+	c.Assert(rec.Cohosts, DeepEquals, []string{"user1@yandex.ru"})
+	c.Assert(rec.Labels, DeepEquals, []string{"team-a", "weekly"})
+	c.Assert(rec.Created.Equal(ts), Equals, true)
+
+	ts = ts.Add(time.Hour)
+
+	info2, err := api.Create(&telemost.Conference{})
+	c.Assert(err, IsNil)
+
+	c.Assert(api.AddCohosts(info2.ID, []string{"USER1@yandex.ru", "user2@yandex.ru"}), IsNil)
+	c.Assert(api.AddCohosts(info2.ID, []string{"user2@yandex.ru"}), IsNil)
+
+	rec, err = reg.Get(info2.ID)
+	c.Assert(err, IsNil)
+	c.Assert(rec.Cohosts, DeepEquals, []string{"USER1@yandex.ru", "user2@yandex.ru"})
+	c.Assert(rec.Labels, IsNil)
+
+	recs, err := reg.FindByCohost("user1@yandex.ru")
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 2)
+	c.Assert(recs[0].ID, Equals, info1.ID)
+	c.Assert(recs[1].ID, Equals, info2.ID)
+
+	c.Assert(api.DeleteCohosts(info2.ID, []string{"user1@yandex.ru", "user2@yandex.ru"}), IsNil)
+
+	recs, err = reg.FindByCohost("user1@yandex.ru")
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 1)
+
+	rec, _ = reg.Get(info2.ID)
+	c.Assert(rec.Cohosts, HasLen, 0)
+
+	c.Assert(api.UpdateCohosts(info2.ID, []string{"user3@yandex.ru"}), IsNil)
+
+	rec, _ = reg.Get(info2.ID)
+	c.Assert(rec.Cohosts, DeepEquals, []string{"user3@yandex.ru"})
+
+	_, err = api.PatchContext(
+		WithLabels(context.Background(), "patched"), info2.ID,
+		telemost.NewPatch().ClearCohosts(),
+	)
+	c.Assert(err, IsNil)
+
+	rec, _ = reg.Get(info2.ID)
+	c.Assert(rec.Cohosts, IsNil)
+	c.Assert(rec.Labels, DeepEquals, []string{"patched"})
+	c.Assert(rec.Updated.Equal(ts), Equals, true)
+
+	recs, err = reg.FindByLabel("team-a")
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 1)
+	c.Assert(recs[0].ID, Equals, info1.ID)
+
+	recs, err = reg.FindCreated(ts, time.Time{})
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 1)
+	c.Assert(recs[0].ID, Equals, info2.ID)
+
+	recs, err = reg.FindCreated(time.Time{}, ts)
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 1)
+	c.Assert(recs[0].ID, Equals, info1.ID)
+
+	c.Assert(reg.SetLabels(info2.ID, "b", "a", "b"), IsNil)
+	rec, _ = reg.Get(info2.ID)
+	c.Assert(rec.Labels, DeepEquals, []string{"a", "b"})
+
+	c.Assert(api.Delete(info1.ID), IsNil)
+
+	recs, err = reg.FindByLabel("team-a")
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 0)
+
+	recs, err = reg.Find(Query{Label: "team-a", WithDeleted: true})
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 1)
+	c.Assert(recs[0].IsDeleted(), Equals, true)
+
+	// Failed requests and unknown conferences are not recorded
+	_, err = api.Get("1")
+	c.Assert(err, NotNil)
+	c.Assert(api.AddCohosts("1", []string{"user1@yandex.ru"}), NotNil)
+
+	_, err = reg.Get("1")
+	c.Assert(err, Equals, ErrNotFound)
+	_, err = reg.Get("")
+	c.Assert(err, Equals, ErrEmptyID)
+	c.Assert(reg.SetLabels("1", "a"), Equals, ErrNotFound)
+	c.Assert(reg.SetLabels("", "a"), Equals, ErrEmptyID)
+}
+
+func (s *RegistrySuite) TestResolveSIP(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	reg, err := New(NewMemoryStore())
+	c.Assert(err, IsNil)
+
+	api, err := telemost.NewClient(
+		"Test1234",
+		telemost.WithBaseURL(srv.URL),
+		telemost.WithSIPResolver(reg),
+	)
+
+	c.Assert(err, IsNil)
+
+	api.Use(reg.Middleware)
+
+	info, err := api.Create(&telemost.Conference{})
+	c.Assert(err, IsNil)
+
+	for _, ref := range []string{info.ID, info.SIPID, info.SIPURIMeeting, "sip:" + info.SIPURIMeeting} {
+		id, err := api.ResolveConferenceID(context.Background(), ref)
+		c.Assert(err, IsNil, Commentf("Ref: %q", ref))
+		c.Assert(id.String(), Equals, info.ID)
+	}
+
+	c.Assert(api.AddCohosts(info.SIPURIMeeting, []string{"user1@yandex.ru"}), IsNil)
+
+	cohosts, err := api.GetCohosts(info.SIPID)
+	c.Assert(err, IsNil)
+	c.Assert(cohosts.Flatten(), DeepEquals, []string{"user1@yandex.ru"})
+
+	_, err = api.ResolveConferenceID(context.Background(), "sip:unknown@sip.t.ya.ru")
+	c.Assert(err, ErrorMatches, `Can't resolve conference ID from "sip:unknown@sip.t.ya.ru": .*`)
+
+	c.Assert(api.Delete(info.SIPURIMeeting), IsNil)
+
+	_, err = api.ResolveConferenceID(context.Background(), info.SIPURIMeeting)
+	c.Assert(err, ErrorMatches, `Can't resolve conference ID .*`)
+
+	_, err = reg.ResolveSIP(context.Background(), "")
+	c.Assert(err, Equals, telemost.ErrUnknownSIP)
+}
+
+func (s *RegistrySuite) TestStoreErrors(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	reg, _ := New(&failingStore{NewMemoryStore()})
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+
+	var errs []error
+
+	reg.OnError = func(err error) { errs = append(errs, err) }
+	api.Use(reg.Middleware)
+
+	_, err := api.Create(&telemost.Conference{})
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0], ErrorMatches, "Can't save record .*: Store is read-only")
+
+	var nilReg *Registry
+
+	_, err = nilReg.Get("1")
+	c.Assert(err, Equals, ErrNilRegistry)
+	_, err = nilReg.Find(Query{})
+	c.Assert(err, Equals, ErrNilRegistry)
+	c.Assert(nilReg.SetLabels("1"), Equals, ErrNilRegistry)
+
+	api.Use(nilReg.Middleware)
+
+	_, err = api.Create(&telemost.Conference{})
+	c.Assert(err, IsNil)
+}
+
+func (s *RegistrySuite) TestFileStore(c *C) {
+	file := c.MkDir() + "/registry.json"
+
+	_, err := NewFileStore("")
+	c.Assert(err, NotNil)
+
+	store, err := NewFileStore(file)
+	c.Assert(err, IsNil)
+
+	testStore(c, store)
+
+	store, err = NewFileStore(file)
+	c.Assert(err, IsNil)
+
+	rec, err := store.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(rec.Labels, DeepEquals, []string{"test"})
+
+	// Failed save doesn't change records in memory
+	store.path = c.MkDir() + "/unknown/registry.json"
+
+	c.Assert(store.Put(&Record{ID: "12345678901234"}), ErrorMatches, "Can't save store file: .*")
+	c.Assert(store.Put(&Record{ID: "98765432109876"}), ErrorMatches, "Can't save store file: .*")
+	c.Assert(store.Remove("12345678901234"), ErrorMatches, "Can't save store file: .*")
+
+	rec, err = store.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(rec.Labels, DeepEquals, []string{"test"})
+	_, err = store.Get("98765432109876")
+	c.Assert(err, Equals, ErrNotFound)
+
+	c.Assert(os.WriteFile(file, []byte("{"), 0600), IsNil)
+
+	_, err = NewFileStore(file)
+	c.Assert(err, ErrorMatches, "Can't decode store file: .*")
+
+	_, err = NewFileStore(c.MkDir())
+	c.Assert(err, ErrorMatches, "Can't read store file: .*")
+}
+
+func (s *RegistrySuite) TestBoltStore(c *C) {
+	file := c.MkDir() + "/registry.db"
+
+	store, err := OpenBoltStore(file)
+	c.Assert(err, IsNil)
+
+	testStore(c, store)
+
+	c.Assert(store.Close(), IsNil)
+
+	store, err = OpenBoltStore(file)
+	c.Assert(err, IsNil)
+
+	rec, err := store.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(rec.Labels, DeepEquals, []string{"test"})
+	c.Assert(store.Close(), IsNil)
+
+	_, err = OpenBoltStore(c.MkDir())
+	c.Assert(err, ErrorMatches, "Can't open database: .*")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type failingStore struct {
+	*MemoryStore
+}
+
+func (s *failingStore) Put(rec *Record) error {
+	return fmt.Errorf("Store is read-only")
+}
+
+// testStore checks basic store operations
+func testStore(c *C, store Store) {
+	_, err := store.Get("12345678901234")
+	c.Assert(err, Equals, ErrNotFound)
+
+	c.Assert(store.Put(nil), NotNil)
+	c.Assert(store.Put(&Record{}), Equals, ErrEmptyID)
+
+	c.Assert(store.Put(&Record{ID: "12345678901234", Labels: []string{"test"}}), IsNil)
+	c.Assert(store.Put(&Record{ID: "98765432109876"}), IsNil)
+
+	rec, err := store.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(rec.Labels, DeepEquals, []string{"test"})
+
+	rec.Labels[0] = "modified"
+
+	rec, _ = store.Get("12345678901234")
+	c.Assert(rec.Labels, DeepEquals, []string{"test"})
+
+	recs, err := store.List()
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 2)
+
+	c.Assert(store.Remove("98765432109876"), IsNil)
+
+	recs, err = store.List()
+	c.Assert(err, IsNil)
+	c.Assert(recs, HasLen, 1)
+}
@@ -0,0 +1,366 @@
+package registry
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/essentialkaos/telemost/internal/fsutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MemoryStore is in-memory store
+type MemoryStore struct {
+	records map[string]json.RawMessage
+	mu      sync.RWMutex
+}
+
+// FileStore is store which keeps all records in a single JSON file
+type FileStore struct {
+	path string
+	mem  *MemoryStore
+	mu   sync.Mutex
+}
+
+// BoltStore is store based on embedded bbolt key/value database
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// boltBucket is name of bucket with records
+var boltBucket = []byte("conferences")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*FileStore)(nil)
+	_ Store = (*BoltStore)(nil)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewMemoryStore creates new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]json.RawMessage)}
+}
+
+// NewFileStore creates new store backed by JSON file. Existing records are
+// loaded from the file if it exists.
+func NewFileStore(path string) (*FileStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("Store file path is empty")
+	}
+
+	s := &FileStore{path: path, mem: NewMemoryStore()}
+	data, err := os.ReadFile(path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("Can't read store file: %w", err)
+	}
+
+	err = json.Unmarshal(data, &s.mem.records)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode store file: %w", err)
+	}
+
+	if s.mem.records == nil {
+		s.mem.records = make(map[string]json.RawMessage)
+	}
+
+	return s, nil
+}
+
+// OpenBoltStore opens or creates bbolt database with given path
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't open database: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Can't create bucket: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns record with given ID
+func (s *MemoryStore) Get(id string) (*Record, error) {
+	s.mu.RLock()
+	data, ok := s.records[id]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return decodeRecord(data)
+}
+
+// Put adds or replaces record
+func (s *MemoryStore) Put(rec *Record) error {
+	data, err := encodeRecord(rec)
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.records[rec.ID] = data
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Remove removes record with given ID
+func (s *MemoryStore) Remove(id string) error {
+	s.mu.Lock()
+	delete(s.records, id)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// raw returns encoded record with given ID
+func (s *MemoryStore) raw(id string) (json.RawMessage, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.records[id]
+
+	return data, ok
+}
+
+// setRaw sets encoded record or removes it if it doesn't exist
+func (s *MemoryStore) setRaw(id string, data json.RawMessage, exists bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if exists {
+		s.records[id] = data
+	} else {
+		delete(s.records, id)
+	}
+}
+
+// List returns all records
+func (s *MemoryStore) List() ([]*Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*Record, 0, len(s.records))
+
+	for _, data := range s.records {
+		rec, err := decodeRecord(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, rec)
+	}
+
+	return result, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns record with given ID
+func (s *FileStore) Get(id string) (*Record, error) {
+	return s.mem.Get(id)
+}
+
+// Put adds or replaces record and saves store file. Record isn't changed in
+// memory if store file can't be saved.
+func (s *FileStore) Put(rec *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := encodeRecord(rec)
+
+	if err != nil {
+		return err
+	}
+
+	prev, exists := s.mem.raw(rec.ID)
+	s.mem.setRaw(rec.ID, data, true)
+
+	err = s.save()
+
+	if err != nil {
+		s.mem.setRaw(rec.ID, prev, exists)
+	}
+
+	return err
+}
+
+// Remove removes record with given ID and saves store file. Record isn't
+// removed from memory if store file can't be saved.
+func (s *FileStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, exists := s.mem.raw(id)
+	s.mem.setRaw(id, nil, false)
+
+	err := s.save()
+
+	if err != nil {
+		s.mem.setRaw(id, prev, exists)
+	}
+
+	return err
+}
+
+// List returns all records
+func (s *FileStore) List() ([]*Record, error) {
+	return s.mem.List()
+}
+
+// save atomically writes all records to store file
+func (s *FileStore) save() error {
+	s.mem.mu.RLock()
+	data, err := json.MarshalIndent(s.mem.records, "", "  ")
+	s.mem.mu.RUnlock()
+
+	if err != nil {
+		return fmt.Errorf("Can't encode records: %w", err)
+	}
+
+	err = fsutil.WriteFileAtomic(s.path, data, 0600)
+
+	if err != nil {
+		return fmt.Errorf("Can't save store file: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns record with given ID
+func (s *BoltStore) Get(id string) (*Record, error) {
+	var rec *Record
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(boltBucket).Get([]byte(id))
+
+		if data == nil {
+			return ErrNotFound
+		}
+
+		var err error
+		rec, err = decodeRecord(data)
+
+		return err
+	})
+
+	return rec, err
+}
+
+// Put adds or replaces record
+func (s *BoltStore) Put(rec *Record) error {
+	data, err := encodeRecord(rec)
+
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Put([]byte(rec.ID), data)
+	})
+}
+
+// Remove removes record with given ID
+func (s *BoltStore) Remove(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete([]byte(id))
+	})
+}
+
+// List returns all records
+func (s *BoltStore) List() ([]*Record, error) {
+	var result []*Record
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).ForEach(func(_, data []byte) error {
+			rec, err := decodeRecord(data)
+
+			if err != nil {
+				return err
+			}
+
+			result = append(result, rec)
+
+			return nil
+		})
+	})
+
+	return result, err
+}
+
+// Close closes database
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// encodeRecord encodes record to JSON
+func encodeRecord(rec *Record) ([]byte, error) {
+	switch {
+	case rec == nil:
+		return nil, fmt.Errorf("Record is nil")
+	case rec.ID == "":
+		return nil, ErrEmptyID
+	}
+
+	data, err := json.Marshal(rec)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't encode record %s: %w", rec.ID, err)
+	}
+
+	return data, nil
+}
+
+// decodeRecord decodes record from JSON
+func decodeRecord(data []byte) (*Record, error) {
+	rec := &Record{}
+	err := json.Unmarshal(data, rec)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode record: %w", err)
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,178 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/req"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RetryPolicy contains configuration of retries for failed requests
+type RetryPolicy struct {
+	MaxAttempts int           // Maximum number of attempts including the first one
+	MinDelay    time.Duration // Delay before the first retry
+	MaxDelay    time.Duration // Maximum delay between attempts (Retry-After can exceed it)
+	Jitter      float64       // Random delay deviation (0-1)
+	Methods     []string      // Methods which can be retried (GET, PUT and DELETE by default)
+
+	// OnAttempt is called after every attempt
+	OnAttempt func(attempt Attempt)
+}
+
+// Attempt contains info about request attempt
+type Attempt struct {
+	Method     string        // Request method
+	Endpoint   string        // API endpoint
+	Num        int           // Attempt number (starting from 1)
+	StatusCode int           // HTTP status code (0 if request wasn't sent)
+	Err        error         // Attempt error
+	Delay      time.Duration // Delay before next attempt (0 if there will be no retries)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultRetryPolicy is recommended retry policy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinDelay:    500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Jitter:      0.2,
+}
+
+// defaultRetryMethods is slice with idempotent methods which can be retried by default
+var defaultRetryMethods = []string{req.GET, req.PUT, req.DELETE}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates retry policy
+func (p RetryPolicy) Validate() error {
+	switch {
+	case p.MaxAttempts < 0:
+		return fmt.Errorf("Maximum number of attempts can't be negative (%d)", p.MaxAttempts)
+	case p.MinDelay < 0:
+		return fmt.Errorf("Minimal delay can't be negative (%s)", p.MinDelay)
+	case p.MaxDelay < 0:
+		return fmt.Errorf("Maximum delay can't be negative (%s)", p.MaxDelay)
+	case p.MaxDelay != 0 && p.MaxDelay < p.MinDelay:
+		return fmt.Errorf("Maximum delay is less than minimal delay (%s < %s)", p.MaxDelay, p.MinDelay)
+	case p.Jitter < 0 || p.Jitter > 1:
+		return fmt.Errorf("Jitter must be in range 0-1 (%g)", p.Jitter)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// next returns delay before next attempt and flag if request must be retried
+func (p RetryPolicy) next(method string, attempt int, err error) (time.Duration, bool) {
+	if err == nil || attempt >= p.MaxAttempts || !p.isRetryableMethod(method) || !isRetryableError(err) {
+		return 0, false
+	}
+
+	delay := p.MinDelay
+
+	for range attempt - 1 {
+		delay *= 2
+
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			delay = p.MaxDelay
+			break
+		}
+	}
+
+	if p.Jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + p.Jitter*(rand.Float64()*2-1)))
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	retryAfter := getRetryAfter(err)
+
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+
+	return delay, true
+}
+
+// notify sends info about attempt to hook
+func (p RetryPolicy) notify(attempt Attempt) {
+	if p.OnAttempt != nil {
+		p.OnAttempt(attempt)
+	}
+}
+
+// isRetryableMethod returns true if request with given method can be retried
+func (p RetryPolicy) isRetryableMethod(method string) bool {
+	if len(p.Methods) == 0 {
+		return slices.Contains(defaultRetryMethods, method)
+	}
+
+	return slices.Contains(p.Methods, method)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isRetryableError returns true if request with given error can be retried
+func isRetryableError(err error) bool {
+	var apiErr *APIError
+	var urlErr *url.Error
+
+	switch {
+	case isContextError(err):
+		return false
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	case errors.As(err, &urlErr):
+		return true
+	}
+
+	return false
+}
+
+// getRetryAfter returns delay from Retry-After header
+func getRetryAfter(err error) time.Duration {
+	var apiErr *APIError
+
+	if !errors.As(err, &apiErr) || apiErr.Header == nil {
+		return 0
+	}
+
+	retryAfter := apiErr.Header.Get("Retry-After")
+
+	if retryAfter == "" {
+		return 0
+	}
+
+	sec, err := strconv.Atoi(retryAfter)
+
+	if err == nil {
+		return time.Duration(max(sec, 0)) * time.Second
+	}
+
+	date, err := http.ParseTime(retryAfter)
+
+	if err != nil {
+		return 0
+	}
+
+	return max(time.Until(date), 0)
+}
@@ -0,0 +1,364 @@
+package scheduler
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Frequency is recurrence frequency
+type Frequency uint8
+
+const (
+	FREQ_DAILY Frequency = iota + 1
+	FREQ_WEEKLY
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Rule is recurrence rule based on subset of RFC 5545 RRULE (FREQ, INTERVAL,
+// BYDAY, COUNT and UNTIL parts)
+type Rule struct {
+	Start    time.Time      // Time of the first occurrence (DTSTART)
+	Freq     Frequency      // Recurrence frequency
+	Interval int            // Interval between days or weeks (1 if zero)
+	ByDay    []time.Weekday // Days of week
+	Count    int            // Maximum number of occurrences (unlimited if zero)
+	Until    time.Time      // Time of the last possible occurrence (inclusive)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// weekdays contains RRULE weekday names
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseRule parses recurrence rule (e.g. "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR") with
+// given time of the first occurrence. Time of day and location of all occurrences
+// are taken from start time.
+func ParseRule(start time.Time, rule string) (*Rule, error) {
+	r := &Rule{Start: start}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+
+	for part := range strings.SplitSeq(rule, ";") {
+		if part == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(part, "=")
+
+		var err error
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			err = r.parseFreq(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositive(value)
+		case "COUNT":
+			r.Count, err = parsePositive(value)
+		case "BYDAY":
+			err = r.parseByDay(value)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		default:
+			err = fmt.Errorf("Unsupported recurrence rule part %q", part)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := r.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates rule
+func (r *Rule) Validate() error {
+	switch {
+	case r == nil:
+		return fmt.Errorf("Recurrence rule is nil")
+	case r.Start.IsZero():
+		return fmt.Errorf("Recurrence start time is empty")
+	case r.Freq != FREQ_DAILY && r.Freq != FREQ_WEEKLY:
+		return fmt.Errorf("Recurrence frequency is not set")
+	case r.Interval < 0:
+		return fmt.Errorf("Invalid recurrence interval %d", r.Interval)
+	case r.Count < 0:
+		return fmt.Errorf("Invalid recurrence count %d", r.Count)
+	case !r.Until.IsZero() && r.Until.Before(r.Start):
+		return fmt.Errorf("Recurrence end time is before start time")
+	}
+
+	return nil
+}
+
+// Occurrences returns iterator over occurrence indexes and times. Iterator is
+// infinite if rule has no count and end time.
+func (r *Rule) Occurrences() iter.Seq2[int, time.Time] {
+	return r.OccurrencesFrom(time.Time{})
+}
+
+// OccurrencesFrom returns iterator over indexes and times of occurrences which
+// start at or after given time. Periods before given time are skipped without
+// iterating over them, so cost of iteration doesn't depend on age of the rule.
+func (r *Rule) OccurrencesFrom(from time.Time) iter.Seq2[int, time.Time] {
+	return func(yield func(int, time.Time) bool) {
+		if r.Validate() != nil {
+			return
+		}
+
+		interval := max(r.Interval, 1)
+		days := r.days()
+
+		// Daily rule with interval multiple of week always hits the same weekday
+		if r.Freq == FREQ_DAILY && interval%7 == 0 && len(days) != 0 &&
+			!slices.Contains(days, mondayOffset(r.Start.Weekday())) {
+			return
+		}
+
+		period, index := r.skip(from, interval, days)
+
+		for ; ; period += interval {
+			for _, t := range r.periodDays(period, days) {
+				switch {
+				case t.Before(r.Start):
+					continue
+				case r.Count > 0 && index >= r.Count,
+					!r.Until.IsZero() && t.After(r.Until):
+					return
+				}
+
+				if !t.Before(from) && !yield(index, t) {
+					return
+				}
+
+				index++
+			}
+		}
+	}
+}
+
+// Between returns occurrences in given time range (inclusive)
+func (r *Rule) Between(from, to time.Time) []time.Time {
+	var result []time.Time
+
+	for _, t := range r.OccurrencesFrom(from) {
+		if t.After(to) {
+			break
+		}
+
+		result = append(result, t)
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseFreq parses FREQ part
+func (r *Rule) parseFreq(value string) error {
+	switch strings.ToUpper(value) {
+	case "DAILY":
+		r.Freq = FREQ_DAILY
+	case "WEEKLY":
+		r.Freq = FREQ_WEEKLY
+	default:
+		return fmt.Errorf("Unsupported recurrence frequency %q", value)
+	}
+
+	return nil
+}
+
+// parseByDay parses BYDAY part
+func (r *Rule) parseByDay(value string) error {
+	for day := range strings.SplitSeq(value, ",") {
+		wd, ok := weekdays[strings.ToUpper(strings.TrimSpace(day))]
+
+		if !ok {
+			return fmt.Errorf("Unknown weekday %q", day)
+		}
+
+		if !slices.Contains(r.ByDay, wd) {
+			r.ByDay = append(r.ByDay, wd)
+		}
+	}
+
+	return nil
+}
+
+// parseUntil parses UNTIL part
+func (r *Rule) parseUntil(value string) error {
+	var err error
+
+	switch len(value) {
+	case 8:
+		r.Until, err = time.ParseInLocation("20060102", value, r.Start.Location())
+		r.Until = r.Until.Add(24*time.Hour - time.Nanosecond)
+	default:
+		r.Until, err = time.Parse("20060102T150405Z", value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Invalid recurrence end time %q", value)
+	}
+
+	return nil
+}
+
+// days returns sorted list of weekday offsets from Monday
+func (r *Rule) days() []int {
+	if len(r.ByDay) == 0 {
+		if r.Freq == FREQ_DAILY {
+			return nil
+		}
+
+		return []int{mondayOffset(r.Start.Weekday())}
+	}
+
+	var result []int
+
+	for _, wd := range r.ByDay {
+		result = append(result, mondayOffset(wd))
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
+
+// periodDays returns candidate occurrences in given period (day or week number
+// since start)
+func (r *Rule) periodDays(period int, days []int) []time.Time {
+	if r.Freq == FREQ_DAILY {
+		t := r.Start.AddDate(0, 0, period)
+
+		if len(days) != 0 && !slices.Contains(days, mondayOffset(t.Weekday())) {
+			return nil
+		}
+
+		return []time.Time{t}
+	}
+
+	weekStart := r.Start.AddDate(0, 0, period*7-mondayOffset(r.Start.Weekday()))
+	result := make([]time.Time, 0, len(days))
+
+	for _, day := range days {
+		result = append(result, weekStart.AddDate(0, 0, day))
+	}
+
+	return result
+}
+
+// skip returns the first period which can contain occurrences at or after given
+// time and number of occurrences in preceding periods. One period before given
+// time is kept, so time of day and DST changes don't affect result.
+func (r *Rule) skip(from time.Time, interval int, days []int) (int, int) {
+	if !from.After(r.Start) {
+		return 0, 0
+	}
+
+	startOffset := mondayOffset(r.Start.Weekday())
+
+	if r.Freq == FREQ_DAILY {
+		periods := daysBetween(r.Start, from)/interval - 1
+
+		if periods <= 0 {
+			return 0, 0
+		}
+
+		if len(days) == 0 {
+			return periods * interval, periods
+		}
+
+		// Weekdays of periods repeat every 7 periods
+		hits := func(n int) int {
+			count := 0
+
+			for i := range n {
+				if slices.Contains(days, (startOffset+i*interval)%7) {
+					count++
+				}
+			}
+
+			return count
+		}
+
+		return periods * interval, periods/7*hits(7) + hits(periods%7)
+	}
+
+	weekStart := r.Start.AddDate(0, 0, -startOffset)
+	periods := daysBetween(weekStart, from)/7/interval - 1
+
+	if periods <= 0 {
+		return 0, 0
+	}
+
+	skipped := periods * len(days)
+
+	// Days of the first week before start time aren't occurrences
+	for _, day := range days {
+		if day < startOffset {
+			skipped--
+		}
+	}
+
+	return periods * interval, skipped
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mondayOffset returns number of days since Monday
+func mondayOffset(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+// daysBetween returns number of calendar days between dates of given times in
+// location of the first one
+func daysBetween(from, to time.Time) int {
+	to = to.In(from.Location())
+
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// parsePositive parses positive integer
+func parsePositive(value string) (int, error) {
+	v, err := strconv.Atoi(value)
+
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("Invalid recurrence rule value %q", value)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,388 @@
+// Package scheduler provides scheduler for recurring conferences
+package scheduler
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/internal/clock"
+	"github.com/essentialkaos/telemost/internal/fsutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_LEAD_TIME is default time before occurrence when conference is created
+const DEFAULT_LEAD_TIME = 24 * time.Hour
+
+// DEFAULT_INTERVAL is default interval between schedule checks
+const DEFAULT_INTERVAL = time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Clock is source of current time
+type Clock = clock.Clock
+
+// Scheduler creates conferences for occurrences of recurrence rule
+type Scheduler struct {
+	// Clock is source of current time (system clock is used if nil)
+	Clock Clock
+
+	// LeadTime is time before occurrence when conference is created
+	// (DEFAULT_LEAD_TIME is used if zero)
+	LeadTime time.Duration
+
+	// Interval is interval between schedule checks in Run (DEFAULT_INTERVAL is
+	// used if zero)
+	Interval time.Duration
+
+	// Rotation is pool of cohosts emails rotated between occurrences
+	Rotation []string
+
+	// RotationSize is number of cohosts from rotation pool added to every
+	// conference (1 if zero)
+	RotationSize int
+
+	// OnError is called if conference can't be created or instances can't be
+	// saved. Creation is retried on the next check.
+	OnError func(err error)
+
+	client    *telemost.Client
+	template  *telemost.Conference
+	rule      *Rule
+	path      string
+	instances map[string]*Instance // Instances by occurrence start time
+
+	mu     sync.Mutex
+	procMu sync.Mutex
+}
+
+// Instance contains info about conference created for occurrence
+type Instance struct {
+	Index   int                      `json:"index"`             // Occurrence index
+	Start   time.Time                `json:"start"`             // Occurrence start time
+	ID      string                   `json:"id"`                // Conference ID
+	JoinURL string                   `json:"join_url"`          // Conference join URL
+	Cohosts []string                 `json:"cohosts,omitempty"` // Conference cohosts
+	Info    *telemost.ConferenceInfo `json:"info,omitempty"`    // Conference info returned by API
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilClient    = fmt.Errorf("Client is nil")
+	ErrNilTemplate  = fmt.Errorf("Conference template is nil")
+	ErrNilScheduler = fmt.Errorf("Scheduler is nil")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// New creates new scheduler which creates conferences using given template for
+// every occurrence of recurrence rule. Created conferences are persisted to the
+// file with given path and loaded from it if the file exists, so conferences
+// aren't created twice after restart. If path is empty, created conferences are
+// kept only in memory.
+func New(client *telemost.Client, template *telemost.Conference, rule *Rule, path string) (*Scheduler, error) {
+	switch {
+	case client == nil:
+		return nil, ErrNilClient
+	case template == nil:
+		return nil, ErrNilTemplate
+	}
+
+	err := rule.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scheduler{
+		client:    client,
+		template:  template,
+		rule:      rule,
+		path:      path,
+		instances: make(map[string]*Instance),
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("Can't read instances: %w", err)
+	}
+
+	var instances map[string]*Instance
+
+	err = json.Unmarshal(data, &instances)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode instances: %w", err)
+	}
+
+	for _, inst := range instances {
+		if inst != nil {
+			s.instances[instanceKey(inst.Start)] = inst
+		}
+	}
+
+	return s, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Process creates conferences for occurrences which start within lead time and
+// returns number of created conferences. Instances are saved after every created
+// conference.
+func (s *Scheduler) Process(ctx context.Context) (int, error) {
+	if s == nil || s.client == nil {
+		return 0, ErrNilScheduler
+	}
+
+	s.procMu.Lock()
+	defer s.procMu.Unlock()
+
+	now := s.clock().Now()
+	horizon := now.Add(s.leadTime())
+
+	err := s.prune(now)
+
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+
+	for index, start := range s.rule.OccurrencesFrom(now) {
+		if start.After(horizon) {
+			break
+		}
+
+		if s.hasInstance(start) {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+
+		conf := s.conference(index)
+		info, err := s.client.CreateContext(ctx, conf)
+
+		if err != nil {
+			s.reportError(fmt.Errorf(
+				"Can't create conference for %s: %w",
+				start.Format(time.RFC3339), err,
+			))
+			continue
+		}
+
+		s.mu.Lock()
+		s.instances[instanceKey(start)] = &Instance{
+			Index:   index,
+			Start:   start,
+			ID:      info.ID,
+			JoinURL: info.JoinURL,
+			Cohosts: conf.CoHosts.Flatten(),
+			Info:    info,
+		}
+		err = s.save()
+		s.mu.Unlock()
+
+		created++
+
+		if err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+// Run creates conferences for upcoming occurrences until context is canceled
+func (s *Scheduler) Run(ctx context.Context) error {
+	if s == nil || s.client == nil {
+		return ErrNilScheduler
+	}
+
+	interval := s.Interval
+
+	if interval <= 0 {
+		interval = DEFAULT_INTERVAL
+	}
+
+	for {
+		_, err := s.Process(ctx)
+
+		if err != nil && ctx.Err() == nil {
+			s.reportError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.clock().After(interval):
+		}
+	}
+}
+
+// Upcoming returns created conferences which haven't started yet sorted by start
+// time
+func (s *Scheduler) Upcoming() []*Instance {
+	if s == nil || s.client == nil {
+		return nil
+	}
+
+	now := s.clock().Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*Instance
+
+	for _, inst := range s.instances {
+		if !inst.Start.Before(now) {
+			instCopy := *inst
+			result = append(result, &instCopy)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b *Instance) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// conference creates conference for occurrence with given index using template
+func (s *Scheduler) conference(index int) *telemost.Conference {
+	conf := &telemost.Conference{WaitingRoomLevel: s.template.WaitingRoomLevel}
+
+	if s.template.LiveStream != nil {
+		stream := *s.template.LiveStream
+		conf.LiveStream = &stream
+	}
+
+	conf.WithCohosts(s.template.CoHosts.Flatten()...)
+
+	if len(s.Rotation) == 0 {
+		return conf
+	}
+
+	size := min(max(s.RotationSize, 1), len(s.Rotation))
+
+	for i := range size {
+		email := s.Rotation[(index*size+i)%len(s.Rotation)]
+
+		if !slices.Contains(conf.CoHosts.Flatten(), email) {
+			conf.WithCohosts(email)
+		}
+	}
+
+	return conf
+}
+
+// hasInstance returns true if conference for occurrence with given start time
+// was already created
+func (s *Scheduler) hasInstance(start time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.instances[instanceKey(start)]
+
+	return ok
+}
+
+// prune removes instances which already started
+func (s *Scheduler) prune(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruned := false
+
+	for key, inst := range s.instances {
+		if inst.Start.Before(now) {
+			delete(s.instances, key)
+			pruned = true
+		}
+	}
+
+	if !pruned {
+		return nil
+	}
+
+	return s.save()
+}
+
+// leadTime returns lead time
+func (s *Scheduler) leadTime() time.Duration {
+	if s.LeadTime <= 0 {
+		return DEFAULT_LEAD_TIME
+	}
+
+	return s.LeadTime
+}
+
+// clock returns scheduler clock
+func (s *Scheduler) clock() Clock {
+	if s.Clock == nil {
+		return clock.System{}
+	}
+
+	return s.Clock
+}
+
+// reportError sends error to error handler
+func (s *Scheduler) reportError(err error) {
+	if s.OnError != nil {
+		s.OnError(err)
+	}
+}
+
+// save atomically writes instances to file
+func (s *Scheduler) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.instances, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("Can't encode instances: %w", err)
+	}
+
+	err = fsutil.WriteFileAtomic(s.path, data, 0600)
+
+	if err != nil {
+		return fmt.Errorf("Can't save instances: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// instanceKey returns key of instance with given occurrence start time
+func instanceKey(start time.Time) string {
+	return start.UTC().Format(time.RFC3339Nano)
+}
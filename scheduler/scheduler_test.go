@@ -0,0 +1,376 @@
+package scheduler
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/internal/clock"
+	"github.com/essentialkaos/telemost/telemosttest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type SchedulerSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&SchedulerSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *SchedulerSuite) TestRule(c *C) {
+	// Wednesday
+	start := time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	r, err := ParseRule(start, "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;")
+	c.Assert(err, IsNil)
+	c.Assert(formatTimes(r.Between(start, start.AddDate(0, 0, 7))), DeepEquals, []string{
+		"2025-06-04 Wed", "2025-06-06 Fri", "2025-06-09 Mon", "2025-06-11 Wed",
+	})
+
+	r, err = ParseRule(start, "FREQ=WEEKLY;INTERVAL=2;COUNT=3")
+	c.Assert(err, IsNil)
+	c.Assert(formatTimes(r.Between(start, start.AddDate(1, 0, 0))), DeepEquals, []string{
+		"2025-06-04 Wed", "2025-06-18 Wed", "2025-07-02 Wed",
+	})
+
+	r, err = ParseRule(start, "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20250610")
+	c.Assert(err, IsNil)
+	c.Assert(formatTimes(r.Between(time.Time{}, start.AddDate(1, 0, 0))), DeepEquals, []string{
+		"2025-06-04 Wed", "2025-06-05 Thu", "2025-06-06 Fri", "2025-06-09 Mon", "2025-06-10 Tue",
+	})
+
+	r, err = ParseRule(start, "freq=daily;interval=3;until=20250610T100000Z")
+	c.Assert(err, IsNil)
+	c.Assert(formatTimes(r.Between(start.Add(time.Hour), start.AddDate(1, 0, 0))), DeepEquals, []string{
+		"2025-06-07 Sat", "2025-06-10 Tue",
+	})
+
+	r, err = ParseRule(start, "FREQ=DAILY;INTERVAL=7;BYDAY=MO")
+	c.Assert(err, IsNil)
+	c.Assert(r.Between(start, start.AddDate(1, 0, 0)), HasLen, 0)
+
+	for index, t := range r.Occurrences() {
+		c.Fatalf("Unexpected occurrence %d %s", index, t)
+	}
+
+	_, err = ParseRule(start, "FREQ=MONTHLY")
+	c.Assert(err, ErrorMatches, `Unsupported recurrence frequency "MONTHLY"`)
+	_, err = ParseRule(start, "FREQ=DAILY;INTERVAL=0")
+	c.Assert(err, ErrorMatches, `Invalid recurrence rule value "0"`)
+	_, err = ParseRule(start, "FREQ=DAILY;BYDAY=XX")
+	c.Assert(err, ErrorMatches, `Unknown weekday "XX"`)
+	_, err = ParseRule(start, "FREQ=DAILY;UNTIL=2025")
+	c.Assert(err, ErrorMatches, `Invalid recurrence end time "2025"`)
+	_, err = ParseRule(start, "FREQ=DAILY;UNTIL=20250101")
+	c.Assert(err, ErrorMatches, "Recurrence end time is before start time")
+	_, err = ParseRule(start, "FREQ=DAILY;BYMONTH=1")
+	c.Assert(err, ErrorMatches, `Unsupported recurrence rule part "BYMONTH=1"`)
+	_, err = ParseRule(start, "")
+	c.Assert(err, ErrorMatches, "Recurrence frequency is not set")
+	_, err = ParseRule(time.Time{}, "FREQ=DAILY")
+	c.Assert(err, ErrorMatches, "Recurrence start time is empty")
+
+	c.Assert((&Rule{Start: start, Freq: FREQ_DAILY, Interval: -1}).Validate(), NotNil)
+	c.Assert((&Rule{Start: start, Freq: FREQ_DAILY, Count: -1}).Validate(), NotNil)
+
+	var nilRule *Rule
+	c.Assert(nilRule.Validate(), ErrorMatches, "Recurrence rule is nil")
+}
+
+func (s *SchedulerSuite) TestOccurrencesFrom(c *C) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	locs := []*time.Location{time.UTC, time.FixedZone("MSK", 3*3600)}
+
+	if loc, err := time.LoadLocation("Europe/Berlin"); err == nil {
+		locs = append(locs, loc)
+	}
+
+	for i := range 1000 {
+		start := time.Date(
+			2020+rnd.IntN(5), time.Month(1+rnd.IntN(12)), 1+rnd.IntN(28),
+			rnd.IntN(24), rnd.IntN(4)*15, 0, 0, locs[rnd.IntN(len(locs))],
+		)
+
+		r := &Rule{
+			Start:    start,
+			Freq:     Frequency(1 + rnd.IntN(2)),
+			Interval: rnd.IntN(10),
+		}
+
+		for wd := range 7 {
+			if rnd.IntN(3) == 0 {
+				r.ByDay = append(r.ByDay, time.Weekday(wd))
+			}
+		}
+
+		if rnd.IntN(3) == 0 {
+			r.Count = 1 + rnd.IntN(200)
+		}
+
+		if rnd.IntN(3) == 0 {
+			r.Until = start.AddDate(0, 0, rnd.IntN(1000))
+		}
+
+		from := start.Add(time.Duration(rnd.Int64N(int64(3*365*24*time.Hour))) - 240*time.Hour)
+
+		var expected, actual []string
+
+		for index, t := range r.Occurrences() {
+			if len(expected) == 20 {
+				break
+			}
+
+			if !t.Before(from) {
+				expected = append(expected, fmt.Sprintf("%d %s", index, t))
+			}
+		}
+
+		for index, t := range r.OccurrencesFrom(from) {
+			if len(actual) == 20 {
+				break
+			}
+
+			actual = append(actual, fmt.Sprintf("%d %s", index, t))
+		}
+
+		c.Assert(actual, DeepEquals, expected, Commentf("Rule %d: %+v, from: %s", i, r, from))
+	}
+}
+
+func (s *SchedulerSuite) TestScheduler(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+
+	// Friday
+	start := time.Date(2025, 6, 6, 10, 0, 0, 0, time.UTC)
+	rule, _ := ParseRule(start, "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR")
+
+	_, err := New(nil, &telemost.Conference{}, rule, "")
+	c.Assert(err, Equals, ErrNilClient)
+	_, err = New(api, nil, rule, "")
+	c.Assert(err, Equals, ErrNilTemplate)
+	_, err = New(api, &telemost.Conference{}, nil, "")
+	c.Assert(err, NotNil)
+
+	template := &telemost.Conference{
+		WaitingRoomLevel: telemost.ROOM_LEVEL_ORG,
+		LiveStream:       &telemost.LiveStream{Title: "Standup"},
+	}
+
+	template.WithCohosts("lead@yandex.ru")
+
+	sched, err := New(api, template, rule, "")
+	c.Assert(err, IsNil)
+
+	clock := clock.NewFake(start.Add(-time.Hour))
+
+	sched.Clock = clock
+	sched.LeadTime = 4*24*time.Hour + time.Hour
+	sched.Rotation = []string{"user1@yandex.ru", "user2@yandex.ru", "user3@yandex.ru"}
+
+	n, err := sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 3)
+
+	upcoming := sched.Upcoming()
+
+	c.Assert(upcoming, HasLen, 3)
+	c.Assert(formatTimes([]time.Time{upcoming[0].Start, upcoming[1].Start, upcoming[2].Start}), DeepEquals, []string{
+		"2025-06-06 Fri", "2025-06-09 Mon", "2025-06-10 Tue",
+	})
+	c.Assert(upcoming[0].JoinURL, Not(Equals), "")
+	c.Assert(upcoming[0].Cohosts, DeepEquals, []string{"lead@yandex.ru", "user1@yandex.ru"})
+	c.Assert(upcoming[1].Cohosts, DeepEquals, []string{"lead@yandex.ru", "user2@yandex.ru"})
+	c.Assert(upcoming[2].Cohosts, DeepEquals, []string{"lead@yandex.ru", "user3@yandex.ru"})
+
+	conf, ok := srv.Conference(upcoming[1].ID)
+	c.Assert(ok, Equals, true)
+	c.Assert(conf.LiveStream, NotNil)
+	c.Assert(conf.LiveStream.Title, Equals, "Standup")
+	c.Assert(template.CoHosts, HasLen, 1)
+
+	// Already created occurrences are skipped
+	n, err = sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+
+	clock.Advance(24 * time.Hour)
+
+	var errs []error
+
+	sched.OnError = func(err error) { errs = append(errs, err) }
+	sched.RotationSize = 2
+
+	srv.FailNext(1, 403)
+
+	n, err = sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0], ErrorMatches, "Can't create conference for 2025-06-11T10:00:00Z: .*")
+
+	n, err = sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+
+	upcoming = sched.Upcoming()
+
+	c.Assert(upcoming, HasLen, 3)
+	c.Assert(upcoming[0].Index, Equals, 1)
+	c.Assert(upcoming[2].Cohosts, DeepEquals, []string{"lead@yandex.ru", "user1@yandex.ru", "user2@yandex.ru"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	clock.Advance(24 * time.Hour)
+
+	go func() { done <- sched.Run(ctx) }()
+
+	for range 100 {
+		if len(sched.Upcoming()) == 4 {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+
+	c.Assert(<-done, Equals, context.Canceled)
+	c.Assert(sched.Upcoming(), HasLen, 4)
+	c.Assert(sched.Upcoming()[3].Index, Equals, 4)
+
+	var nilSched *Scheduler
+
+	_, err = nilSched.Process(context.Background())
+	c.Assert(err, Equals, ErrNilScheduler)
+	c.Assert(nilSched.Run(context.Background()), Equals, ErrNilScheduler)
+	c.Assert(nilSched.Upcoming(), IsNil)
+}
+
+func (s *SchedulerSuite) TestPersistence(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+	file := c.MkDir() + "/instances.json"
+
+	// Friday
+	start := time.Date(2025, 6, 6, 10, 0, 0, 0, time.UTC)
+	rule, _ := ParseRule(start, "FREQ=DAILY")
+	template := &telemost.Conference{}
+	clock := clock.NewFake(start.Add(-time.Hour))
+
+	sched, err := New(api, template, rule, file)
+	c.Assert(err, IsNil)
+
+	sched.Clock = clock
+	sched.LeadTime = 2*24*time.Hour + time.Hour
+
+	n, err := sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 3)
+
+	// Created conferences survive restart
+	sched, err = New(api, template, rule, file)
+	c.Assert(err, IsNil)
+
+	sched.Clock = clock
+	sched.LeadTime = 2*24*time.Hour + time.Hour
+
+	c.Assert(sched.Upcoming(), HasLen, 3)
+
+	n, err = sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+	c.Assert(srv.Conferences(), HasLen, 3)
+
+	// Started occurrences are removed from file
+	clock.Advance(24 * time.Hour)
+
+	n, err = sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+
+	sched, err = New(api, template, rule, file)
+	c.Assert(err, IsNil)
+
+	sched.Clock = clock
+
+	upcoming := sched.Upcoming()
+
+	c.Assert(upcoming, HasLen, 3)
+	c.Assert(upcoming[0].Index, Equals, 1)
+	c.Assert(upcoming[0].Info, NotNil)
+	c.Assert(upcoming[0].ID, Equals, upcoming[0].Info.ID)
+
+	// Instances are bound to occurrence start time, so they aren't reused for
+	// occurrences of changed rule with the same index
+	rule, _ = ParseRule(start.Add(30*time.Minute), "FREQ=DAILY")
+	sched, err = New(api, template, rule, file)
+	c.Assert(err, IsNil)
+
+	sched.Clock = clock
+	sched.LeadTime = 2*24*time.Hour + time.Hour
+
+	n, err = sched.Process(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 2)
+	c.Assert(sched.Upcoming(), HasLen, 5)
+}
+
+func (s *SchedulerSuite) TestErrors(c *C) {
+	api, _ := telemost.NewClient("Test1234")
+	dir := c.MkDir()
+	rule, _ := ParseRule(time.Now().Add(time.Hour), "FREQ=DAILY")
+
+	c.Assert(os.WriteFile(dir+"/instances.json", []byte("{"), 0600), IsNil)
+
+	_, err := New(api, &telemost.Conference{}, rule, dir+"/instances.json")
+	c.Assert(err, ErrorMatches, "Can't decode instances: .*")
+
+	_, err = New(api, &telemost.Conference{}, rule, dir)
+	c.Assert(err, ErrorMatches, "Can't read instances: .*")
+
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	api, _ = telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+	sched, _ := New(api, &telemost.Conference{}, rule, dir+"/unknown/instances.json")
+
+	n, err := sched.Process(context.Background())
+	c.Assert(err, ErrorMatches, "Can't save instances: .*")
+	c.Assert(n, Equals, 1)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// formatTimes formats times as dates with weekdays
+func formatTimes(times []time.Time) []string {
+	var result []string
+
+	for _, t := range times {
+		result = append(result, t.Format("2006-01-02 Mon"))
+	}
+
+	return result
+}
@@ -8,40 +8,75 @@ package telemost
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/essentialkaos/ek/v13/req"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-const (
-	ROOM_LEVEL_PUBLIC  = "PUBLIC"
-	ROOM_LEVEL_ORG     = "ORGANIZATION"
-	ROOM_LEVEL_ADMINS  = "ADMINS"
-	ROOM_LEVEL_UNKNOWN = "UNKNOWN"
-)
+// DEFAULT_PAGE_SIZE is default number of items fetched per request
+const DEFAULT_PAGE_SIZE = 256
+
+// MAX_COHOSTS is maximum number of conference cohosts
+const MAX_COHOSTS = 30
+
+// MAX_COHOSTS_PAGES is safety ceiling on number of cohosts pages fetched for one
+// conference. It protects from endless pagination if API ignores offset and isn't
+// a limit of API itself.
+const MAX_COHOSTS_PAGES = 1000
 
+// Operations names
 const (
-	ACCESS_LEVEL_PUBLIC  = "PUBLIC"
-	ACCESS_LEVEL_ORG     = "ORGANIZATION"
-	ACCESS_LEVEL_UNKNOWN = "UNKNOWN"
+	OP_CREATE         = "Create"
+	OP_GET            = "Get"
+	OP_UPDATE         = "Update"
+	OP_DELETE         = "Delete"
+	OP_GET_COHOSTS    = "GetCohosts"
+	OP_ADD_COHOSTS    = "AddCohosts"
+	OP_UPDATE_COHOSTS = "UpdateCohosts"
+	OP_DELETE_COHOSTS = "DeleteCohosts"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Client is Yandex.Telemost API client
 type Client struct {
-	engine *req.Engine
-	token  string
+	engine   *req.Engine
+	tokens   TokenSource
+	baseURL  string
+	retry    RetryPolicy
+	limiter  *Limiter
+	pageSize int
+	logger   *slog.Logger
+	tracer   Tracer
+	metrics  Metrics
+	sip      SIPResolver
+
+	mwMu        sync.RWMutex
+	middlewares []Middleware
 }
 
 // Conference contains basic info about conference
 type Conference struct {
-	WaitingRoomLevel string      `json:"waiting_room_level,omitempty"`
-	LiveStream       *LiveStream `json:"live_stream,omitempty"`
-	CoHosts          Hosts       `json:"cohosts,omitempty"`
+	WaitingRoomLevel WaitingRoomLevel `json:"waiting_room_level,omitempty"`
+	LiveStream       *LiveStream      `json:"live_stream,omitempty"`
+	CoHosts          Hosts            `json:"cohosts,omitempty"`
 }
 
 // ConferenceInfo contains information about an existing conference
@@ -56,10 +91,10 @@ type ConferenceInfo struct {
 
 // LiveStream contains info about conference stream
 type LiveStream struct {
-	WatchURL    string `json:"watch_url"`
-	AccessLevel string `json:"access_level"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	WatchURL    string      `json:"watch_url"`
+	AccessLevel AccessLevel `json:"access_level"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
 }
 
 // Host contains info about host
@@ -72,15 +107,19 @@ type Hosts []*Host
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// apiError contains info
-type apiError struct {
-	Code        string `json:"error"`
-	Description string `json:"description"`
+// APIError contains info about error returned by API
+type APIError struct {
+	StatusCode  int         `json:"-"`
+	Code        string      `json:"error"`
+	Description string      `json:"description"`
+	Message     string      `json:"message"`
+	RequestID   string      `json:"-"`
+	Header      http.Header `json:"-"`
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// API is URL of Yandex.Telemost API
+// API is default URL of Yandex.Telemost API used by clients without custom base URL
 var API = "https://cloud-api.yandex.net/v1/telemost-api/conferences"
 
 var (
@@ -91,15 +130,55 @@ var (
 	ErrNilConference = fmt.Errorf("Conference is nil")
 )
 
+var (
+	ErrUnauthorized = fmt.Errorf("Unauthorized")
+	ErrForbidden    = fmt.Errorf("Forbidden")
+	ErrNotFound     = fmt.Errorf("Not found")
+	ErrConflict     = fmt.Errorf("Conflict")
+	ErrRateLimited  = fmt.Errorf("Rate limited")
+)
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// NewClient creates new client instance
-func NewClient(token string) (*Client, error) {
+// NewClient creates new client instance with static token
+func NewClient(token string, opts ...Option) (*Client, error) {
 	if token == "" {
 		return nil, ErrEmptyToken
 	}
 
-	c := &Client{engine: &req.Engine{}, token: token}
+	return NewClientWithTokenSource(StaticToken(token), opts...)
+}
+
+// NewClientWithTokenSource creates new client instance with given token source
+func NewClientWithTokenSource(tokens TokenSource, opts ...Option) (*Client, error) {
+	if tokens == nil {
+		return nil, ErrNilTokenSource
+	}
+
+	cfg := &config{pageSize: DEFAULT_PAGE_SIZE}
+	err := cfg.apply(opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		engine:   cfg.engine(),
+		tokens:   tokens,
+		baseURL:  cfg.baseURL,
+		retry:    cfg.retry,
+		limiter:  cfg.limiter,
+		pageSize: cfg.pageSize,
+		logger:   cfg.logger,
+		tracer:   cfg.tracer,
+		metrics:  cfg.metrics,
+		sip:      cfg.sip,
+	}
+
+	if cfg.cache != nil {
+		c.Use(cfg.cache.middleware(c.apiURL))
+	}
+
 	c.SetUserAgent("", "")
 
 	return c, nil
@@ -126,6 +205,11 @@ func (c *Client) SetUserAgent(app, version string) {
 //
 // https://yandex.ru/dev/telemost/doc/ru/conference-create
 func (c *Client) Create(conf *Conference) (*ConferenceInfo, error) {
+	return c.CreateContext(context.Background(), conf)
+}
+
+// CreateContext creates new conference or broadcast using given context
+func (c *Client) CreateContext(ctx context.Context, conf *Conference) (*ConferenceInfo, error) {
 	switch {
 	case c == nil || c.engine == nil:
 		return nil, ErrNilClient
@@ -139,8 +223,21 @@ func (c *Client) Create(conf *Conference) (*ConferenceInfo, error) {
 		return nil, err
 	}
 
+	ctx, span := c.startSpan(ctx, OP_CREATE, "", len(conf.CoHosts))
+
 	info := &ConferenceInfo{}
-	err = c.sendRequest(req.POST, "", info, conf, nil)
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_CREATE,
+		Method:    req.POST,
+		Payload:   conf,
+		Response:  info,
+	})
+
+	if err == nil {
+		span.SetConferenceID(info.ID)
+	}
+
+	span.End(0, err)
 
 	if err != nil {
 		return nil, err
@@ -153,15 +250,34 @@ func (c *Client) Create(conf *Conference) (*ConferenceInfo, error) {
 //
 // https://yandex.ru/dev/telemost/doc/ru/conference-read
 func (c *Client) Get(id string) (*ConferenceInfo, error) {
+	return c.GetContext(context.Background(), id)
+}
+
+// GetContext fetches info about conference or broadcast using given context
+func (c *Client) GetContext(ctx context.Context, id string) (*ConferenceInfo, error) {
 	switch {
 	case c == nil || c.engine == nil:
 		return nil, ErrNilClient
-	case id == "":
-		return nil, ErrEmptyID
 	}
 
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := c.startSpan(ctx, OP_GET, id, 0)
+
 	info := &ConferenceInfo{}
-	err := c.sendRequest(req.GET, "/"+id, info, nil, nil)
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_GET,
+		Method:    req.GET,
+		Endpoint:  "/" + id,
+		ID:        id,
+		Response:  info,
+	})
+
+	span.End(0, err)
 
 	if err != nil {
 		return nil, err
@@ -174,23 +290,43 @@ func (c *Client) Get(id string) (*ConferenceInfo, error) {
 //
 // https://yandex.ru/dev/telemost/doc/ru/conference-update
 func (c *Client) Update(id string, conf *Conference) (*ConferenceInfo, error) {
+	return c.UpdateContext(context.Background(), id, conf)
+}
+
+// UpdateContext updates conference or broadcast using given context
+func (c *Client) UpdateContext(ctx context.Context, id string, conf *Conference) (*ConferenceInfo, error) {
 	switch {
 	case c == nil || c.engine == nil:
 		return nil, ErrNilClient
-	case id == "":
-		return nil, ErrEmptyID
 	case conf == nil:
 		return nil, ErrNilConference
 	}
 
-	err := validateConference(conf)
+	id, err := c.resolveID(ctx, id)
 
 	if err != nil {
 		return nil, err
 	}
 
+	err = validateConference(conf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := c.startSpan(ctx, OP_UPDATE, id, len(conf.CoHosts))
+
 	info := &ConferenceInfo{}
-	err = c.sendRequest(req.PATCH, "/"+id, info, conf, nil)
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_UPDATE,
+		Method:    req.PATCH,
+		Endpoint:  "/" + id,
+		ID:        id,
+		Payload:   conf,
+		Response:  info,
+	})
+
+	span.End(0, err)
 
 	if err != nil {
 		return nil, err
@@ -201,104 +337,243 @@ func (c *Client) Update(id string, conf *Conference) (*ConferenceInfo, error) {
 
 // Delete cancels conference or broadcast
 func (c *Client) Delete(id string) error {
+	return c.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext cancels conference or broadcast using given context
+func (c *Client) DeleteContext(ctx context.Context, id string) error {
 	switch {
 	case c == nil || c.engine == nil:
 		return ErrNilClient
-	case id == "":
-		return ErrEmptyID
 	}
 
-	return c.sendRequest(req.DELETE, "/"+id, nil, nil, nil)
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, span := c.startSpan(ctx, OP_DELETE, id, 0)
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_DELETE,
+		Method:    req.DELETE,
+		Endpoint:  "/" + id,
+		ID:        id,
+	})
+
+	span.End(0, err)
+
+	return err
 }
 
 // GetCohosts fetches slice with all cohosts
 //
 // https://yandex.ru/dev/telemost/doc/ru/cohosts-read
 func (c *Client) GetCohosts(id string) (Hosts, error) {
-	switch {
-	case c == nil || c.engine == nil:
-		return nil, ErrNilClient
-	case id == "":
-		return nil, ErrEmptyID
-	}
+	return c.GetCohostsContext(context.Background(), id)
+}
 
-	resp := &struct {
-		Cohosts Hosts `json:"cohosts"`
-	}{}
+// GetCohostsContext fetches slice with all cohosts using given context
+func (c *Client) GetCohostsContext(ctx context.Context, id string) (Hosts, error) {
+	return c.GetAllCohosts(ctx, id)
+}
 
-	err := c.sendRequest(
-		req.GET, "/"+id+"/cohosts", resp, nil,
-		req.Query{"offset": 0, "limit": 256},
-	)
+// GetAllCohosts fetches all pages with cohosts
+func (c *Client) GetAllCohosts(ctx context.Context, id string) (Hosts, error) {
+	var result Hosts
 
-	if err != nil {
-		return nil, err
+	for host, err := range c.CohostsIter(ctx, id) {
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, host)
 	}
 
-	return resp.Cohosts, nil
+	return result, nil
+}
+
+// CohostsIter returns iterator over all conference cohosts which fetches pages
+// on demand. API may return less cohosts than requested page size, so iteration
+// stops on empty page or on page shorter than previous ones. It fails if API
+// returns the same page twice or more than MAX_COHOSTS_PAGES pages.
+func (c *Client) CohostsIter(ctx context.Context, id string) iter.Seq2[*Host, error] {
+	return func(yield func(*Host, error) bool) {
+		if c == nil || c.engine == nil {
+			yield(nil, ErrNilClient)
+			return
+		}
+
+		id, err := c.resolveID(ctx, id)
+
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		ctx, span := c.startSpan(ctx, OP_GET_COHOSTS, id, 0)
+
+		defer func() {
+			span.End(0, err)
+		}()
+
+		var prevPage Hosts
+
+		// Number of cohosts API actually returns for full page
+		limit := 0
+
+		for offset, pageNum := 0, 1; ; pageNum++ {
+			var page Hosts
+
+			page, err = c.getCohostsPage(ctx, id, offset)
+
+			if err == nil {
+				err = checkCohostsPage(page, prevPage, offset, pageNum)
+			}
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			span.SetCohostsCount(offset + len(page))
+
+			for _, host := range page {
+				if !yield(host, nil) {
+					return
+				}
+			}
+
+			if len(page) == 0 || len(page) < limit {
+				return
+			}
+
+			limit = max(limit, len(page))
+			prevPage = page
+			offset += len(page)
+		}
+	}
 }
 
 // AddCohosts appends given hosts to conference cohosts
 //
 // https://yandex.ru/dev/telemost/doc/ru/cohosts-add
 func (c *Client) AddCohosts(id string, emails []string) error {
+	return c.AddCohostsContext(context.Background(), id, emails)
+}
+
+// AddCohostsContext appends given hosts to conference cohosts using given context
+func (c *Client) AddCohostsContext(ctx context.Context, id string, emails []string) error {
 	switch {
 	case c == nil || c.engine == nil:
 		return ErrNilClient
-	case id == "":
-		return ErrEmptyID
 	case len(emails) == 0:
 		return ErrEmptyCohosts
 	}
 
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
 	payload := &struct {
 		Cohosts Hosts `json:"cohosts"`
 	}{
 		Cohosts: convertHosts(emails),
 	}
 
-	return c.sendRequest(req.PATCH, "/"+id+"/cohosts", nil, payload, nil)
+	ctx, span := c.startSpan(ctx, OP_ADD_COHOSTS, id, len(emails))
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_ADD_COHOSTS,
+		Method:    req.PATCH,
+		Endpoint:  "/" + id + "/cohosts",
+		ID:        id,
+		Payload:   payload,
+	})
+
+	span.End(0, err)
+
+	return err
 }
 
 // UpdateCohosts updates conference cohosts
 //
 // https://yandex.ru/dev/telemost/doc/ru/cohosts-update
 func (c *Client) UpdateCohosts(id string, emails []string) error {
+	return c.UpdateCohostsContext(context.Background(), id, emails)
+}
+
+// UpdateCohostsContext updates conference cohosts using given context
+func (c *Client) UpdateCohostsContext(ctx context.Context, id string, emails []string) error {
 	switch {
 	case c == nil || c.engine == nil:
 		return ErrNilClient
-	case id == "":
-		return ErrEmptyID
 	case len(emails) == 0:
 		return ErrEmptyCohosts
 	}
 
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
 	payload := &struct {
 		Cohosts Hosts `json:"cohosts"`
 	}{
 		Cohosts: convertHosts(emails),
 	}
 
-	return c.sendRequest(req.PUT, "/"+id+"/cohosts", nil, payload, nil)
+	ctx, span := c.startSpan(ctx, OP_UPDATE_COHOSTS, id, len(emails))
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_UPDATE_COHOSTS,
+		Method:    req.PUT,
+		Endpoint:  "/" + id + "/cohosts",
+		ID:        id,
+		Payload:   payload,
+	})
+
+	span.End(0, err)
+
+	return err
 }
 
 // DeleteCohosts removes given hosts from chosts of conference
 //
 // https://yandex.ru/dev/telemost/doc/ru/cohosts-del
 func (c *Client) DeleteCohosts(id string, emails []string) error {
+	return c.DeleteCohostsContext(context.Background(), id, emails)
+}
+
+// DeleteCohostsContext removes given hosts from chosts of conference using given
+// context
+func (c *Client) DeleteCohostsContext(ctx context.Context, id string, emails []string) error {
 	switch {
 	case c == nil || c.engine == nil:
 		return ErrNilClient
-	case id == "":
-		return ErrEmptyID
 	case len(emails) == 0:
 		return ErrEmptyCohosts
 	}
 
-	return c.sendRequest(
-		req.DELETE, "/"+id+"/cohosts", nil, nil,
-		req.Query{"cohost_emails": emails},
-	)
+	id, err := c.resolveID(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, span := c.startSpan(ctx, OP_DELETE_COHOSTS, id, len(emails))
+	err = c.sendRequest(ctx, &Request{
+		Operation: OP_DELETE_COHOSTS,
+		Method:    req.DELETE,
+		Endpoint:  "/" + id + "/cohosts",
+		ID:        id,
+		Query:     url.Values{"cohost_emails": emails},
+	})
+
+	span.End(0, err)
+
+	return err
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -329,63 +604,302 @@ func (h Hosts) Flatten() []string {
 	return result
 }
 
+// Error returns error message
+func (e *APIError) Error() string {
+	if e.Code == "" && e.Description == "" {
+		return fmt.Sprintf("API returned non-ok status code %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("API returned error: %s (%s)", e.Description, e.Code)
+}
+
+// Is checks if error matches one of sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// sendRequest sends request to API
-func (c *Client) sendRequest(method, endpoint string, response, payload any, query req.Query) error {
-	r := req.Request{
-		Method:  method,
-		URL:     API + endpoint,
-		Query:   query,
-		Accept:  req.CONTENT_TYPE_JSON,
-		Headers: req.Headers{"Authorization": "OAuth " + c.token},
+// sendRequest sends request to API through middleware chain
+func (c *Client) sendRequest(ctx context.Context, r *Request) error {
+	return c.chain()(ctx, r)
+}
+
+// send sends request to API with retries
+func (c *Client) send(ctx context.Context, r *Request) error {
+	reqURL := c.apiURL() + r.Endpoint
+
+	if len(r.Query) != 0 {
+		reqURL += "?" + encodeQuery(r.Query)
+	}
+
+	var data []byte
+
+	if r.Payload != nil {
+		var err error
+		data, err = json.Marshal(r.Payload)
+
+		if err != nil {
+			return fmt.Errorf("Can't encode request payload: %w", err)
+		}
+	}
+
+	// Number of attempts made with refreshed token which don't count against
+	// retry policy limits
+	refreshes := 0
+
+	for attempt := 1; ; attempt++ {
+		token, err := c.sendTracedAttempt(ctx, r, reqURL, data, attempt)
+		rf, canRefresh := c.tokens.(TokenRefresher)
+
+		if refreshes == 0 && canRefresh && errors.Is(err, ErrUnauthorized) {
+			c.retry.notify(Attempt{
+				Method:     r.Method,
+				Endpoint:   r.Endpoint,
+				Num:        attempt,
+				StatusCode: getStatusCode(err),
+				Err:        err,
+			})
+
+			rfErr := rf.Refresh(ctx, token)
+
+			if rfErr != nil {
+				return fmt.Errorf("Can't refresh token: %w", rfErr)
+			}
+
+			refreshes++
+
+			continue
+		}
+
+		delay, retry := c.retry.next(r.Method, attempt-refreshes, err)
+
+		c.retry.notify(Attempt{
+			Method:     r.Method,
+			Endpoint:   r.Endpoint,
+			Num:        attempt,
+			StatusCode: getStatusCode(err),
+			Err:        err,
+			Delay:      delay,
+		})
+
+		if !retry {
+			return err
+		}
+
+		if c.metrics != nil {
+			c.metrics.ObserveRetry(r.Operation)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Can't send request to API: %w", ctx.Err())
+		case <-time.After(delay):
+		}
 	}
+}
 
-	if payload != nil {
-		r.ContentType = req.CONTENT_TYPE_JSON
-		r.Body = payload
+// sendTracedAttempt sends single request to API within its own span, logs
+// the result and updates metrics. Time spent on getting token and waiting for
+// rate limiter isn't included into request latency. It returns token used for
+// the request.
+func (c *Client) sendTracedAttempt(ctx context.Context, r *Request, reqURL string, data []byte, attempt int) (string, error) {
+	token, err := c.tokens.Token(ctx)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't get token: %w", err)
 	}
 
-	resp, err := c.engine.Do(r)
+	err = c.waitLimiter(ctx, r.Operation)
 
 	if err != nil {
-		return fmt.Errorf("Can't send request to API: %w", err)
+		return token, fmt.Errorf("Can't send request to API: %w", err)
 	}
 
-	if resp.StatusCode > 299 {
-		apiErr := &apiError{}
-		err = resp.JSON(apiErr)
+	ctx, span := c.startAttemptSpan(ctx, r.Method, r.Endpoint, attempt)
 
-		if err == nil {
-			return fmt.Errorf("API returned error: %s (%s)", apiErr.Description, apiErr.Code)
-		} else {
-			return fmt.Errorf("API returned non-ok status code %d", resp.StatusCode)
-		}
+	start := time.Now()
+	statusCode, err := c.sendAttempt(ctx, r, reqURL, data, token)
+	latency := time.Since(start)
+
+	c.logAttempt(ctx, r.Method, r.Endpoint, attempt, statusCode, latency, err)
+	span.End(statusCode, err)
+
+	if c.metrics != nil {
+		c.metrics.ObserveRequest(r.Operation, statusCode, latency, err)
+	}
+
+	return token, err
+}
+
+// sendAttempt sends single request to API using given token
+func (c *Client) sendAttempt(ctx context.Context, r *Request, reqURL string, data []byte, token string) (int, error) {
+	var body io.Reader
+
+	if data != nil {
+		body = bytes.NewReader(data)
 	}
 
-	if response != nil {
-		err = resp.JSON(response)
+	hr, err := http.NewRequestWithContext(ctx, r.Method, reqURL, body)
+
+	if err != nil {
+		return 0, fmt.Errorf("Can't create request: %w", err)
+	}
+
+	hr.Header.Set("Accept", req.CONTENT_TYPE_JSON)
+	hr.Header.Set("Authorization", "OAuth "+token)
+	hr.Header.Set("User-Agent", c.engine.UserAgent)
+
+	c.injectTraceContext(ctx, hr.Header)
+
+	if data != nil {
+		hr.Header.Set("Content-Type", req.CONTENT_TYPE_JSON)
+	}
+
+	c.logRequest(ctx, hr, data)
+
+	resp, err := c.engine.Client.Do(hr)
+
+	if err != nil {
+		return 0, fmt.Errorf("Can't send request to API: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	c.logResponse(ctx, resp)
+
+	if resp.StatusCode > 299 {
+		return resp.StatusCode, decodeAPIError(resp)
+	}
+
+	if r.Response != nil {
+		err = json.NewDecoder(resp.Body).Decode(r.Response)
 
 		if err != nil {
-			return fmt.Errorf("Can't decode API response: %w", err)
+			return resp.StatusCode, fmt.Errorf("Can't decode API response: %w", err)
 		}
 	}
 
+	return resp.StatusCode, nil
+}
+
+// waitLimiter waits for rate limiter and reports wait time to metrics
+func (c *Client) waitLimiter(ctx context.Context, op string) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	start := time.Now()
+	err := c.limiter.Wait(ctx)
+
+	if c.metrics != nil {
+		c.metrics.ObserveRateLimitWait(op, time.Since(start))
+	}
+
+	return err
+}
+
+// getCohostsPage fetches one page with cohosts
+func (c *Client) getCohostsPage(ctx context.Context, id string, offset int) (Hosts, error) {
+	resp := &struct {
+		Cohosts Hosts `json:"cohosts"`
+	}{}
+
+	err := c.sendRequest(ctx, &Request{
+		Operation: OP_GET_COHOSTS,
+		Method:    req.GET,
+		Endpoint:  "/" + id + "/cohosts",
+		ID:        id,
+		Query: url.Values{
+			"offset": {strconv.Itoa(offset)},
+			"limit":  {strconv.Itoa(c.pageSize)},
+		},
+		Response: resp,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Cohosts, nil
+}
+
+// checkCohostsPage checks that cohosts pagination makes progress
+func checkCohostsPage(page, prevPage Hosts, offset, pageNum int) error {
+	switch {
+	case pageNum > MAX_COHOSTS_PAGES:
+		return fmt.Errorf("API returned more than %d pages of cohosts", MAX_COHOSTS_PAGES)
+	case len(page) != 0 && slices.Equal(page.Flatten(), prevPage.Flatten()):
+		return fmt.Errorf("API returned the same page of cohosts twice (offset: %d)", offset)
+	}
+
 	return nil
 }
 
+// apiURL returns API base URL used by client
+func (c *Client) apiURL() string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+
+	return API
+}
+
+// decodeAPIError decodes error from API response
+func decodeAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{}
+	err := json.NewDecoder(resp.Body).Decode(apiErr)
+
+	if err != nil {
+		apiErr = &APIError{}
+	}
+
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.Header = resp.Header
+	apiErr.RequestID = resp.Header.Get("X-Request-Id")
+
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get("Yandex-Cloud-Request-ID")
+	}
+
+	return apiErr
+}
+
+// getStatusCode returns HTTP status code from API error
+func getStatusCode(err error) int {
+	var apiErr *APIError
+
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // validateConference validates conference settings
 func validateConference(conf *Conference) error {
 	switch {
-	case conf.WaitingRoomLevel != "" &&
-		!slices.Contains([]string{ROOM_LEVEL_PUBLIC, ROOM_LEVEL_ORG, ROOM_LEVEL_ADMINS, ROOM_LEVEL_UNKNOWN}, conf.WaitingRoomLevel):
-		return fmt.Errorf("Unknown waiting room level %q", conf.WaitingRoomLevel)
+	case !conf.WaitingRoomLevel.IsZero() && !conf.WaitingRoomLevel.isAllowed():
+		return fmt.Errorf("Unknown waiting room level %q", conf.WaitingRoomLevel.Raw())
 
-	case conf.LiveStream != nil && conf.LiveStream.AccessLevel != "" &&
-		!slices.Contains([]string{ACCESS_LEVEL_PUBLIC, ACCESS_LEVEL_ORG, ACCESS_LEVEL_UNKNOWN}, conf.LiveStream.AccessLevel):
-		return fmt.Errorf("Unknown live stream access level %q", conf.LiveStream.AccessLevel)
+	case conf.LiveStream != nil && !conf.LiveStream.AccessLevel.IsZero() &&
+		!conf.LiveStream.AccessLevel.isAllowed():
+		return fmt.Errorf("Unknown live stream access level %q", conf.LiveStream.AccessLevel.Raw())
 
 	case conf.LiveStream != nil && len(conf.LiveStream.Title) > 1024:
 		return fmt.Errorf("Live stream title exceeds maximum length (%d > 1024)", len(conf.LiveStream.Title))
@@ -393,13 +907,38 @@ func validateConference(conf *Conference) error {
 	case conf.LiveStream != nil && len(conf.LiveStream.Description) > 2048:
 		return fmt.Errorf("Live stream description exceeds maximum length (%d > 2048)", len(conf.LiveStream.Description))
 
-	case len(conf.CoHosts) > 30:
-		return fmt.Errorf("Too many cohosts (%d > 30)", len(conf.CoHosts))
+	case len(conf.CoHosts) > MAX_COHOSTS:
+		return fmt.Errorf("Too many cohosts (%d > %d)", len(conf.CoHosts), MAX_COHOSTS)
 	}
 
 	return nil
 }
 
+// encodeQuery encodes query parameters sorted by key. Multiple values of one
+// parameter are joined with unescaped comma.
+func encodeQuery(query url.Values) string {
+	var buf strings.Builder
+
+	for _, k := range slices.Sorted(maps.Keys(query)) {
+		if buf.Len() > 0 {
+			buf.WriteByte('&')
+		}
+
+		buf.WriteString(url.QueryEscape(k))
+		buf.WriteByte('=')
+
+		for i, v := range query[k] {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			buf.WriteString(url.QueryEscape(v))
+		}
+	}
+
+	return buf.String()
+}
+
 // convertHosts converts slice with emails to hosts
 func convertHosts(emails []string) Hosts {
 	var result Hosts
@@ -8,8 +8,24 @@ package telemost
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -22,176 +38,1912 @@ const TEST_PORT = "56123"
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+var (
+	flakyCounter  atomic.Int32
+	flakyFailures atomic.Int32
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 func Test(t *testing.T) { TestingT(t) }
 
-type TelemostSuite struct{}
+type TelemostSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&TelemostSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelemostSuite) SetUpSuite(c *C) {
+	API = "http://127.0.0.1:" + TEST_PORT
+
+	mux := http.NewServeMux()
+	server := &http.Server{Addr: ":" + TEST_PORT, Handler: mux}
+
+	mux.HandleFunc("POST /", handlerCreateConference)
+	mux.HandleFunc("GET /12345678901234", handlerGetConference)
+	mux.HandleFunc("PATCH /12345678901234", handlerUpdateConference)
+	mux.HandleFunc("DELETE /12345678901234", handlerDeleteConference)
+	mux.HandleFunc("GET /12345678901234/cohosts", handlerGetCohosts)
+	mux.HandleFunc("PATCH /12345678901234/cohosts", handlerAddCohosts)
+	mux.HandleFunc("PUT /12345678901234/cohosts", handlerUpdateCohosts)
+	mux.HandleFunc("DELETE /12345678901234/cohosts", handlerDeleteCohosts)
+	mux.HandleFunc("GET /98765432109876", handlerSlowConference)
+	mux.HandleFunc("GET /55555555555555/cohosts", handlerPagedCohosts(5))
+	mux.HandleFunc("GET /66666666666666/cohosts", handlerPagedCohosts(math.MaxInt32))
+	mux.HandleFunc("GET /88888888888888/cohosts", handlerPagedCohosts(45))
+	mux.HandleFunc("GET /44444444444444/cohosts", handlerCappedCohosts(250, 100))
+	mux.HandleFunc("GET /77777777777777/cohosts", handlerRepeatedCohosts)
+	mux.HandleFunc("GET /flaky/{id}", handlerFlaky)
+	mux.HandleFunc("POST /flaky", handlerFlaky)
+
+	go server.ListenAndServe()
+
+	time.Sleep(time.Second)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelemostSuite) TestConferenceValidation(c *C) {
+	conf := &Conference{WaitingRoomLevel: WaitingRoomLevel("TEST")}
+	c.Assert(validateConference(conf).Error(), Equals, `Unknown waiting room level "TEST"`)
+
+	conf = &Conference{LiveStream: &LiveStream{AccessLevel: AccessLevel("TEST")}}
+	c.Assert(validateConference(conf).Error(), Equals, `Unknown live stream access level "TEST"`)
+
+	conf = &Conference{LiveStream: &LiveStream{AccessLevel: ACCESS_LEVEL_PUBLIC, Title: strings.Repeat("TEST1234", 180)}}
+	c.Assert(validateConference(conf).Error(), Equals, `Live stream title exceeds maximum length (1440 > 1024)`)
+
+	conf = &Conference{LiveStream: &LiveStream{AccessLevel: ACCESS_LEVEL_PUBLIC, Description: strings.Repeat("TEST1234", 300)}}
+	c.Assert(validateConference(conf).Error(), Equals, `Live stream description exceeds maximum length (2400 > 2048)`)
+
+	var hosts Hosts
+
+	for range 50 {
+		hosts = append(hosts, &Host{`test@test.com`})
+	}
+
+	conf = &Conference{CoHosts: hosts}
+	c.Assert(validateConference(conf).Error(), Equals, `Too many cohosts (50 > 30)`)
+}
+
+func (s *TelemostSuite) TestLevels(c *C) {
+	l, err := ParseWaitingRoomLevel("admins")
+	c.Assert(err, IsNil)
+	c.Assert(l, Equals, ROOM_LEVEL_ADMINS)
+	c.Assert(l.String(), Equals, "ADMINS")
+	c.Assert(l.IsValid(), Equals, true)
+
+	_, err = ParseWaitingRoomLevel("unknown")
+	c.Assert(err, ErrorMatches, `Unknown waiting room level "unknown"`)
+	_, err = ParseWaitingRoomLevel("")
+	c.Assert(err, ErrorMatches, `Unknown waiting room level ""`)
+
+	a, err := ParseAccessLevel(" Organization ")
+	c.Assert(err, IsNil)
+	c.Assert(a, Equals, ACCESS_LEVEL_ORG)
+	c.Assert(a.IsValid(), Equals, true)
+
+	_, err = ParseAccessLevel("ADMINS")
+	c.Assert(err, ErrorMatches, `Unknown live stream access level "ADMINS"`)
+
+	var conf Conference
+
+	err = json.Unmarshal([]byte(`{"waiting_room_level":"EVERYONE","live_stream":{"access_level":"FRIENDS"}}`), &conf)
+
+	c.Assert(err, IsNil)
+	c.Assert(conf.WaitingRoomLevel.String(), Equals, "UNKNOWN")
+	c.Assert(conf.WaitingRoomLevel.Raw(), Equals, "EVERYONE")
+	c.Assert(conf.WaitingRoomLevel.IsValid(), Equals, false)
+	c.Assert(conf.LiveStream.AccessLevel.String(), Equals, "UNKNOWN")
+	c.Assert(conf.LiveStream.AccessLevel.Raw(), Equals, "FRIENDS")
+	c.Assert(conf.LiveStream.AccessLevel.IsValid(), Equals, false)
+
+	data, err := json.Marshal(conf)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"waiting_room_level":"EVERYONE","live_stream":{"watch_url":"","access_level":"FRIENDS","title":"","description":""}}`)
+	c.Assert(validateConference(&conf), ErrorMatches, `Unknown waiting room level "EVERYONE"`)
+	c.Assert(NewPatch().SetAccessLevel(conf.LiveStream.AccessLevel).Validate(), ErrorMatches, `Unknown live stream access level "FRIENDS"`)
+	c.Assert(validateConference(&Conference{WaitingRoomLevel: "PUBLICC"}), ErrorMatches, `Unknown waiting room level "PUBLICC"`)
+
+	c.Assert(json.Unmarshal([]byte(`{"waiting_room_level":1}`), &conf), ErrorMatches, `Can't decode waiting room level: .*`)
+	c.Assert(json.Unmarshal([]byte(`{"live_stream":{"access_level":[]}}`), &conf), ErrorMatches, `Can't decode live stream access level: .*`)
+
+	data, err = json.Marshal(Conference{LiveStream: &LiveStream{}})
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"live_stream":{"watch_url":"","access_level":"","title":"","description":""}}`)
+
+	err = json.Unmarshal([]byte(`{"waiting_room_level":"ADMINS"}`), &conf)
+	c.Assert(err, IsNil)
+	c.Assert(conf.WaitingRoomLevel, Equals, ROOM_LEVEL_ADMINS)
+
+	c.Assert(json.Unmarshal([]byte(`{"waiting_room_level":1}`), &conf), NotNil)
+	c.Assert(json.Unmarshal([]byte(`{"live_stream":{"access_level":1}}`), &conf), NotNil)
+
+	c.Assert(ROOM_LEVEL_UNKNOWN.IsValid(), Equals, false)
+	c.Assert(ACCESS_LEVEL_UNKNOWN.IsValid(), Equals, false)
+	c.Assert(WaitingRoomLevel("").IsZero(), Equals, true)
+	c.Assert(AccessLevel("").IsZero(), Equals, true)
+	c.Assert(WaitingRoomLevel("").String(), Equals, "")
+	c.Assert(AccessLevel("").String(), Equals, "")
+
+	// UNKNOWN received from API must not break updates
+	err = json.Unmarshal([]byte(`{"waiting_room_level":"UNKNOWN","live_stream":{"access_level":"UNKNOWN"}}`), &conf)
+	c.Assert(err, IsNil)
+	c.Assert(conf.WaitingRoomLevel, Equals, ROOM_LEVEL_UNKNOWN)
+	c.Assert(conf.LiveStream.AccessLevel, Equals, ACCESS_LEVEL_UNKNOWN)
+	c.Assert(validateConference(&conf), IsNil)
+	c.Assert(NewPatch().SetWaitingRoomLevel(conf.WaitingRoomLevel).SetAccessLevel(conf.LiveStream.AccessLevel).Validate(), IsNil)
+
+	conf = Conference{WaitingRoomLevel: "PUBLIC"}
+	c.Assert(conf.WaitingRoomLevel, Equals, ROOM_LEVEL_PUBLIC)
+}
+
+func (s *TelemostSuite) TestGet(c *C) {
+	api, _ := NewClient("Test1234")
+	info, err := api.Get("12345678901234")
+
+	api.SetUserAgent("Test", "1.2.3")
+
+	c.Assert(err, IsNil)
+	c.Assert(info, NotNil)
+
+	c.Assert(info.ID, Equals, "12345678901234")
+	c.Assert(info.WaitingRoomLevel, Equals, ROOM_LEVEL_ORG)
+	c.Assert(info.LiveStream.WatchURL, Equals, "https://telemost.yandex.ru/live/123456789abcdef0123456789abcdef0")
+	c.Assert(info.LiveStream.AccessLevel, Equals, ACCESS_LEVEL_PUBLIC)
+	c.Assert(info.LiveStream.Title, Equals, "Example conference created via API")
+	c.Assert(info.LiveStream.Description, Equals, "Some description of example conference created via API")
+	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/12345678901234")
+	c.Assert(info.SIPURIMeeting, Equals, "12345678901234567890@sip.t.ya.ru")
+	c.Assert(info.SIPURITelemost, Equals, "j@sip.t.ya.ru")
+	c.Assert(info.SIPID, Equals, "12345678901234567890")
+}
+
+func (s *TelemostSuite) TestCreate(c *C) {
+	conf := &Conference{}
+
+	api, _ := NewClient("Test1234")
+	info, err := api.Create(conf.WithCohosts("user@domain.com"))
+
+	c.Assert(err, IsNil)
+	c.Assert(info, NotNil)
+
+	c.Assert(info.ID, Equals, "12345678901234")
+	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/12345678901234")
+	c.Assert(info.LiveStream.WatchURL, Equals, "https://telemost.yandex.ru/live/123456789abcdef0123456789abcdef0")
+
+	_, err = api.Create(&Conference{WaitingRoomLevel: WaitingRoomLevel("TEST")})
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, `Unknown waiting room level "TEST"`)
+
+	api, _ = NewClient("http-error")
+	_, err = api.Create(&Conference{})
+
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, `API returned error: Conference not found. (ConferenceNotFound)`)
+}
+
+func (s *TelemostSuite) TestUpdate(c *C) {
+	api, _ := NewClient("Test1234")
+	info, err := api.Update("12345678901234", &Conference{LiveStream: &LiveStream{
+		AccessLevel: ACCESS_LEVEL_PUBLIC,
+		Title:       "Example conference created via API",
+		Description: "Some description of example conference created via API",
+	}})
+
+	c.Assert(err, IsNil)
+	c.Assert(info, NotNil)
+
+	c.Assert(info.LiveStream.AccessLevel, Equals, ACCESS_LEVEL_PUBLIC)
+	c.Assert(info.LiveStream.Title, Equals, "Example conference created via API")
+	c.Assert(info.LiveStream.Description, Equals, "Some description of example conference created via API")
+
+	_, err = api.Update("12345678901234", &Conference{WaitingRoomLevel: WaitingRoomLevel("TEST")})
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, `Unknown waiting room level "TEST"`)
+
+	api, _ = NewClient("http-error")
+	_, err = api.Update("12345678901234", &Conference{})
+
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, `API returned error: Conference not found. (ConferenceNotFound)`)
+
+}
+
+func (s *TelemostSuite) TestPatch(c *C) {
+	p := NewPatch().
+		ClearWaitingRoomLevel().
+		SetTitle("").
+		ClearDescription().
+		SetAccessLevel(ACCESS_LEVEL_ORG)
+
+	data, err := json.Marshal(p)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"live_stream":{"access_level":"ORGANIZATION","description":null,"title":""},"waiting_room_level":null}`)
+
+	p = NewPatch().SetWaitingRoomLevel(ROOM_LEVEL_ADMINS).SetCohosts("user1@yandex.ru").ClearLiveStream()
+
+	data, err = json.Marshal(p)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"cohosts":[{"email":"user1@yandex.ru"}],"live_stream":null,"waiting_room_level":"ADMINS"}`)
+
+	data, err = json.Marshal(NewPatch().ClearCohosts().ClearAccessLevel().ClearTitle().SetDescription("Test"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"cohosts":null,"live_stream":{"access_level":null,"description":"Test","title":null}}`)
+
+	var nilPatch *ConferencePatch
+
+	data, err = json.Marshal(nilPatch)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `null`)
+
+	c.Assert(nilPatch.IsEmpty(), Equals, true)
+	c.Assert(nilPatch.Validate(), Equals, ErrNilPatch)
+	c.Assert(NewPatch().Validate(), Equals, ErrEmptyPatch)
+	c.Assert(NewPatch().SetWaitingRoomLevel("").Validate(), ErrorMatches, `Unknown waiting room level ""`)
+	c.Assert(NewPatch().SetWaitingRoomLevel(ROOM_LEVEL_UNKNOWN).Validate(), IsNil)
+	c.Assert(NewPatch().SetWaitingRoomLevel(WaitingRoomLevel("everyone")).Validate(), ErrorMatches, `Unknown waiting room level "everyone"`)
+	c.Assert(NewPatch().SetAccessLevel(AccessLevel("TEST")).Validate(), ErrorMatches, `Unknown live stream access level "TEST"`)
+	c.Assert(NewPatch().ClearLiveStream().SetTitle("Test").Validate(), ErrorMatches, `Live stream can't be cleared and modified at the same time`)
+	c.Assert(NewPatch().SetTitle(strings.Repeat("TEST1234", 180)).Validate(), ErrorMatches, `Live stream title exceeds maximum length \(1440 > 1024\)`)
+	c.Assert(NewPatch().ClearTitle().Validate(), IsNil)
+	c.Assert(errors.Is(NewPatch().SetCohosts().Validate(), ErrEmptyCohosts), Equals, true)
+	c.Assert(NewPatch().SetCohosts().Validate(), ErrorMatches, `Cohosts slice is empty \(use ClearCohosts to remove all cohosts\)`)
+	c.Assert(NewPatch().ClearCohosts().Validate(), IsNil)
+
+	api, _ := NewClient("Test1234")
+
+	info, err := api.Patch("12345678901234", NewPatch().SetTitle("Example conference created via API"))
+	c.Assert(err, IsNil)
+	c.Assert(info, NotNil)
+
+	_, err = api.Patch("12345678901234", nil)
+	c.Assert(err, Equals, ErrNilPatch)
+	_, err = api.Patch("", NewPatch())
+	c.Assert(err, Equals, ErrEmptyID)
+
+	api, _ = NewClient("http-error")
+	_, err = api.Patch("12345678901234", NewPatch().ClearTitle())
+	c.Assert(err, NotNil)
+
+	var nilClient *Client
+	_, err = nilClient.Patch("12345678901234", NewPatch())
+	c.Assert(err, Equals, ErrNilClient)
+}
+
+func (s *TelemostSuite) TestDelete(c *C) {
+	api, _ := NewClient("Test1234")
+	err := api.Delete("12345678901234")
+
+	c.Assert(err, IsNil)
+}
+
+func (s *TelemostSuite) TestConferenceID(c *C) {
+	for _, ref := range []string{
+		"12345678901234",
+		" 12345678901234 ",
+		"https://telemost.yandex.ru/j/12345678901234",
+		"https://telemost.360.yandex.ru/j/12345678901234/?source=mail",
+		"http://TELEMOST.YANDEX.RU/j/12345678901234",
+	} {
+		id, err := ParseConferenceID(ref)
+		c.Assert(err, IsNil, Commentf("Ref: %q", ref))
+		c.Assert(id, Equals, ConferenceID("12345678901234"))
+		c.Assert(id.String(), Equals, "12345678901234")
+	}
+
+	_, err := ParseConferenceID("")
+	c.Assert(err, Equals, ErrEmptyID)
+	_, err = ParseConferenceID("1234567890123A")
+	c.Assert(err, ErrorMatches, `Invalid conference ID "1234567890123A"`)
+	_, err = ParseConferenceID("https://example.com/j/12345678901234")
+	c.Assert(err, ErrorMatches, `Invalid conference join URL "https://example.com/j/12345678901234"`)
+	_, err = ParseConferenceID("https://telemost.yandex.ru/live/12345678901234")
+	c.Assert(err, ErrorMatches, `Invalid conference join URL .*`)
+	_, err = ParseConferenceID("https://telemost.yandex.ru/j/")
+	c.Assert(err, ErrorMatches, `Invalid conference join URL .*`)
+	_, err = ParseConferenceID("12345678901234567890@sip.t.ya.ru")
+	c.Assert(err, ErrorMatches, `Conference ID can't be parsed from SIP URI "12345678901234567890@sip.t.ya.ru"`)
+	_, err = ParseConferenceID("sip:12345678901234@sip.telemost.yandex.ru")
+	c.Assert(err, ErrorMatches, `Conference ID can't be parsed from SIP URI .*`)
+
+	id, err := ParseConferenceID("1234")
+	c.Assert(err, IsNil)
+	c.Assert(id.String(), Equals, "1234")
+
+	_, err = ParseConferenceID("12345678901234567890")
+	c.Assert(err, ErrorMatches, `Conference ID can't be parsed from SIP ID "12345678901234567890"`)
+
+	api, _ := NewClient("Test1234")
+
+	for _, ref := range []string{
+		"https://telemost.yandex.ru/j/12345678901234",
+		"https://telemost.yandex.ru/j/12345678901234?from=user@yandex.ru",
+		"HTTPS://user@telemost.yandex.ru/j/12345678901234",
+	} {
+		id, err = api.ResolveConferenceID(context.Background(), ref)
+		c.Assert(err, IsNil, Commentf("Ref: %q", ref))
+		c.Assert(id.String(), Equals, "12345678901234")
+	}
+
+	_, err = api.ResolveConferenceID(context.Background(), "user@yandex.ru")
+	c.Assert(err, ErrorMatches, `Invalid conference ID "user@yandex.ru"`)
+	_, err = api.ResolveConferenceID(context.Background(), "12345678901234567890")
+	c.Assert(err, ErrorMatches, `Can't resolve SIP URI or SIP ID "12345678901234567890": SIP resolver is not set`)
+	_, err = api.ResolveConferenceID(context.Background(), "12345678901234567890@sip.t.ya.ru")
+	c.Assert(err, ErrorMatches, `Can't resolve SIP URI or SIP ID "12345678901234567890@sip.t.ya.ru": SIP resolver is not set`)
+	_, err = api.ResolveConferenceID(context.Background(), "test")
+	c.Assert(err, ErrorMatches, `Invalid conference ID "test"`)
+
+	info, err := api.Get("https://telemost.yandex.ru/j/12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "12345678901234")
+	_, err = api.Get("12345678901234567890")
+	c.Assert(err, ErrorMatches, `Can't resolve SIP URI or SIP ID .*: SIP resolver is not set`)
+	_, err = api.Get("")
+	c.Assert(err, Equals, ErrEmptyID)
+
+	resolver := mapResolver{
+		"12345678901234567890":             "12345678901234",
+		"12345678901234567890@sip.t.ya.ru": "12345678901234",
+		"00000000000000000001@sip.t.ya.ru": "test",
+	}
+
+	_, err = NewClient("Test1234", WithSIPResolver(nil))
+	c.Assert(err, Equals, ErrNilSIPResolver)
+
+	api, err = NewClient("Test1234", WithSIPResolver(resolver))
+	c.Assert(err, IsNil)
+
+	for _, ref := range []string{"12345678901234", "12345678901234567890", "SIP:12345678901234567890@sip.t.ya.ru"} {
+		id, err = api.ResolveConferenceID(context.Background(), ref)
+		c.Assert(err, IsNil, Commentf("Ref: %q", ref))
+		c.Assert(id.String(), Equals, "12345678901234")
+	}
+
+	info, err = api.Get("12345678901234567890")
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "12345678901234")
+	info, err = api.Patch("sip:12345678901234567890@sip.t.ya.ru", NewPatch().SetTitle("Test"))
+	c.Assert(err, IsNil)
+	c.Assert(info, NotNil)
+	info, err = api.Update("https://telemost.yandex.ru/j/12345678901234", &Conference{})
+	c.Assert(err, IsNil)
+	c.Assert(info, NotNil)
+
+	cohosts, err := api.GetCohosts("12345678901234567890@sip.t.ya.ru")
+	c.Assert(err, IsNil)
+	c.Assert(cohosts, NotNil)
+
+	c.Assert(api.AddCohosts("12345678901234567890", []string{"user1@yandex.ru"}), IsNil)
+	c.Assert(api.UpdateCohosts("https://telemost.yandex.ru/j/12345678901234", []string{"user1@yandex.ru"}), IsNil)
+	c.Assert(api.DeleteCohosts("12345678901234567890", []string{"user1@yandex.ru"}), IsNil)
+	_, err = api.SyncCohosts("12345678901234567890", nil)
+	c.Assert(err, IsNil)
+	c.Assert(api.Delete("12345678901234567890"), IsNil)
+
+	_, err = api.Get("00000000000000000000")
+	c.Assert(err, ErrorMatches, `Can't resolve conference ID from "00000000000000000000": .*`)
+	c.Assert(api.Delete("00000000000000000000"), NotNil)
+
+	_, err = api.ResolveConferenceID(context.Background(), "00000000000000000000")
+	c.Assert(err, ErrorMatches, `Can't resolve conference ID from "00000000000000000000": .*`)
+	_, err = api.ResolveConferenceID(context.Background(), "00000000000000000001@sip.t.ya.ru")
+	c.Assert(err, ErrorMatches, `Invalid conference ID "test"`)
+
+	var nilClient *Client
+
+	_, err = nilClient.ResolveConferenceID(context.Background(), "12345678901234")
+	c.Assert(err, Equals, ErrNilClient)
+}
+
+func (s *TelemostSuite) TestBatch(c *C) {
+	api, _ := NewClient("Test1234")
+
+	var inFlight, maxInFlight atomic.Int32
+
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			n := inFlight.Add(1)
+
+			for {
+				m := maxInFlight.Load()
+
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			defer inFlight.Add(-1)
+
+			return next(ctx, r)
+		}
+	})
+
+	confs := make([]*Conference, 10)
+
+	for i := range confs {
+		confs[i] = &Conference{}
+	}
+
+	confs[3] = nil
+
+	results := api.BatchCreate(context.Background(), confs, 3)
+
+	c.Assert(results, HasLen, 10)
+	c.Assert(maxInFlight.Load() <= 3, Equals, true)
+
+	for i, r := range results {
+		if i == 3 {
+			c.Assert(r.Err, Equals, ErrNilConference)
+			continue
+		}
+
+		c.Assert(r.Err, IsNil)
+		c.Assert(r.Value.ID, Equals, "12345678901234")
+	}
+
+	maxInFlight.Store(0)
+
+	results = api.BatchGet(context.Background(), []string{"12345678901234", "", "1", "12345678901234"}, 0)
+
+	c.Assert(results, HasLen, 4)
+	c.Assert(results[0].Err, IsNil)
+	c.Assert(results[0].Value.ID, Equals, "12345678901234")
+	c.Assert(results[1].Err, Equals, ErrEmptyID)
+	c.Assert(results[2].Err, NotNil)
+	c.Assert(results[3].Err, IsNil)
+	c.Assert(maxInFlight.Load() <= DEFAULT_BATCH_CONCURRENCY, Equals, true)
+
+	errs := api.BatchDelete(context.Background(), []string{"12345678901234", ""}, 5)
+
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0], IsNil)
+	c.Assert(errs[1], Equals, ErrEmptyID)
+
+	c.Assert(api.BatchGet(context.Background(), nil, 1), HasLen, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	results = api.BatchGet(ctx, []string{"12345678901234", "98765432109876", "12345678901234", "12345678901234"}, 1)
+
+	c.Assert(results, HasLen, 4)
+	c.Assert(results[0].Err, IsNil)
+	c.Assert(errors.Is(results[1].Err, context.DeadlineExceeded), Equals, true)
+	c.Assert(results[2].Err, Equals, context.DeadlineExceeded)
+	c.Assert(results[3].Err, Equals, context.DeadlineExceeded)
+}
+
+func (s *TelemostSuite) TestCache(c *C) {
+	_, err := NewCache(0, 10)
+	c.Assert(err, ErrorMatches, "Cache TTL must be greater than 0 \\(0s\\)")
+
+	_, err = NewClient("Test1234", WithCache(nil))
+	c.Assert(err, Equals, ErrNilCache)
+
+	cache, err := NewCache(time.Minute, 0)
+	c.Assert(err, IsNil)
+	c.Assert(cache.size, Equals, DEFAULT_CACHE_SIZE)
+
+	api, err := NewClient("Test1234", WithCache(cache))
+	c.Assert(err, IsNil)
+
+	var calls atomic.Int32
+
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			calls.Add(1)
+			time.Sleep(25 * time.Millisecond)
+			return next(ctx, r)
+		}
+	})
+
+	info, err := api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "12345678901234")
+
+	info.ID = "modified"
+
+	info, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "12345678901234")
+	c.Assert(calls.Load(), Equals, int32(1))
+
+	cohosts, err := api.GetCohosts("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(cohosts, HasLen, 2)
+
+	_, err = api.GetCohosts("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(calls.Load(), Equals, int32(3))
+	c.Assert(cache.Len(), Equals, 3)
+	c.Assert(cache.Stats(), DeepEquals, CacheStats{Hits: 3, Misses: 3})
+
+	c.Assert(api.AddCohosts("12345678901234", []string{"user3@yandex.ru"}), IsNil)
+	c.Assert(cache.Len(), Equals, 0)
+
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(calls.Load(), Equals, int32(5))
+
+	_, err = api.Update("12345678901234", &Conference{WaitingRoomLevel: ROOM_LEVEL_PUBLIC})
+	c.Assert(err, IsNil)
+	c.Assert(cache.Len(), Equals, 0)
+
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(api.Delete("12345678901234"), IsNil)
+	c.Assert(cache.Len(), Equals, 0)
+
+	calls.Store(0)
+
+	wg := sync.WaitGroup{}
+	errs := make([]error, 5)
+
+	for i := range errs {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			_, errs[i] = api.Get("12345678901234")
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		c.Assert(err, IsNil)
+	}
+
+	c.Assert(calls.Load(), Equals, int32(1))
+	c.Assert(cache.Stats().Shared, Equals, int64(4))
+
+	cache.Purge()
+	c.Assert(cache.Len(), Equals, 0)
+
+	cache, _ = NewCache(50*time.Millisecond, 1)
+	api, _ = NewClient("Test1234", WithCache(cache))
+
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	_, err = api.GetCohosts("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(cache.Len(), Equals, 1)
+	c.Assert(cache.Stats().Evictions, Equals, int64(2))
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = api.GetCohosts("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(cache.Stats().Misses, Equals, int64(5))
+
+	api, _ = NewClient("http-error", WithCache(cache))
+	cache.Purge()
+
+	_, err = api.Get("12345678901234")
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+	c.Assert(cache.Len(), Equals, 0)
+
+	// Clients with different base URLs don't share cached data
+	otherServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte(`{"id": "12345678901234", "join_url": "https://telemost.yandex.ru/j/00000000000000"}`))
+	}))
+
+	defer otherServer.Close()
+
+	cache, _ = NewCache(time.Minute, 0)
+	api, _ = NewClient("Test1234", WithCache(cache))
+	otherAPI, _ := NewClient("Test1234", WithCache(cache), WithBaseURL(otherServer.URL))
+
+	info, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/12345678901234")
+	info, err = otherAPI.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/00000000000000")
+	c.Assert(cache.Len(), Equals, 2)
+
+	// Changing of default API URL doesn't lead to serving data of old endpoint
+	defaultAPI := API
+	API = otherServer.URL
+
+	info, err = api.Get("12345678901234")
+	API = defaultAPI
+
+	c.Assert(err, IsNil)
+	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/00000000000000")
+
+	// Canceling of shared fetch doesn't fail other callers
+	cache.Purge()
+
+	fetching := make(chan bool, 2)
+
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			fetching <- true
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("Can't send request to API: %w", ctx.Err())
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			return next(ctx, r)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	ownerErr := make(chan error)
+
+	go func() {
+		_, err := api.GetContext(ctx, "12345678901234")
+		ownerErr <- err
+	}()
+
+	<-fetching
+
+	info, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "12345678901234")
+	c.Assert(errors.Is(<-ownerErr, context.DeadlineExceeded), Equals, true)
+	c.Assert(cache.Stats().Shared, Equals, int64(1))
+
+	// Invalidation during fetch affects only requests for given conference
+	cache, _ = NewCache(time.Minute, 0)
+	api, _ = NewClient("Test1234", WithCache(cache))
+
+	started, release := make(chan bool, 2), make(chan bool)
+
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			started <- true
+			<-release
+			return next(ctx, r)
+		}
+	})
+
+	done := make(chan error, 2)
+	get := func() {
+		_, err := api.Get("12345678901234")
+		done <- err
+	}
+
+	go get()
+	<-started
+	cache.Invalidate("98765432109876")
+	release <- true
+	c.Assert(<-done, IsNil)
+	c.Assert(cache.Len(), Equals, 1)
+
+	cache.Purge()
+
+	go get()
+	<-started
+	cache.Invalidate("12345678901234")
+	go get()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		c.Fatal("Request started after invalidation waits for stale request")
+	}
+
+	close(release)
+	c.Assert(<-done, IsNil)
+	c.Assert(<-done, IsNil)
+	c.Assert(cache.Len(), Equals, 1)
+	c.Assert(cache.Stats().Shared, Equals, int64(0))
+
+	// Callers waiting for invalidated fetch fetch data again
+	cache, _ = NewCache(time.Minute, 0)
+	api, _ = NewClient("Test1234", WithCache(cache))
+
+	started, release = make(chan bool, 2), make(chan bool)
+
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			started <- true
+			<-release
+			return next(ctx, r)
+		}
+	})
+
+	go get()
+	<-started
+	go get()
+
+	for cache.Stats().Shared == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cache.Invalidate("12345678901234")
+	release <- true
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		c.Fatal("Caller waiting for invalidated fetch doesn't fetch data again")
+	}
+
+	release <- true
+	c.Assert(<-done, IsNil)
+	c.Assert(<-done, IsNil)
+	c.Assert(cache.Stats().Misses, Equals, int64(2))
+	c.Assert(cache.Len(), Equals, 1)
+
+	var nilCache *Cache
+
+	nilCache.Invalidate("12345678901234")
+	nilCache.Purge()
+	c.Assert(nilCache.Len(), Equals, 0)
+	c.Assert(nilCache.Stats(), DeepEquals, CacheStats{})
+}
+
+func (s *TelemostSuite) TestTemplates(c *C) {
+	dir := c.MkDir()
+
+	c.Assert(os.WriteFile(dir+"/templates.json", []byte(`{
+  "support": {
+    "waiting_room_level": "PUBLIC",
+    "access_level": "ORGANIZATION",
+    "title": "Support session #{{.ticket}}",
+    "description": "{{.team}} support for {{.customer}}",
+    "cohosts": ["support@yandex.ru"],
+    "vars": {"team": "L1"}
+  },
+  "standup": {"title": "Daily standup"}
+}`), 0644), IsNil)
+
+	tmpls, err := LoadTemplates(dir + "/templates.json")
+	c.Assert(err, IsNil)
+	c.Assert(tmpls.Names(), DeepEquals, []string{"standup", "support"})
+
+	tmpl, err := tmpls.Get("support")
+	c.Assert(err, IsNil)
+	c.Assert(tmpl.Name, Equals, "support")
+
+	_, err = tmpls.Get("unknown")
+	c.Assert(err, ErrorMatches, `Unknown template "unknown"`)
+
+	conf, err := tmpl.Render(map[string]any{"ticket": 1234, "customer": "ACME"}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(conf.WaitingRoomLevel, Equals, ROOM_LEVEL_PUBLIC)
+	c.Assert(conf.LiveStream.AccessLevel, Equals, ACCESS_LEVEL_ORG)
+	c.Assert(conf.LiveStream.Title, Equals, "Support session #1234")
+	c.Assert(conf.LiveStream.Description, Equals, "L1 support for ACME")
+	c.Assert(conf.CoHosts.Flatten(), DeepEquals, []string{"support@yandex.ru"})
+
+	conf, err = tmpl.Render(
+		map[string]any{"ticket": 1, "customer": "ACME", "team": "L2"},
+		(&Conference{
+			WaitingRoomLevel: ROOM_LEVEL_ADMINS,
+			LiveStream:       &LiveStream{Title: "Escalation"},
+		}).WithCohosts("support@yandex.ru", "l2@yandex.ru"),
+	)
+	c.Assert(err, IsNil)
+	c.Assert(conf.WaitingRoomLevel, Equals, ROOM_LEVEL_ADMINS)
+	c.Assert(conf.LiveStream.AccessLevel, Equals, ACCESS_LEVEL_ORG)
+	c.Assert(conf.LiveStream.Title, Equals, "Escalation")
+	c.Assert(conf.LiveStream.Description, Equals, "L2 support for ACME")
+	c.Assert(conf.CoHosts.Flatten(), DeepEquals, []string{"support@yandex.ru", "l2@yandex.ru"})
+	c.Assert(tmpl.Vars["team"], Equals, "L1")
+
+	_, err = tmpl.Render(map[string]any{"ticket": 1}, nil)
+	c.Assert(err, ErrorMatches, `Can't render description of template "support": .*`)
+
+	_, err = tmpl.Render(map[string]any{"ticket": 1, "customer": strings.Repeat("A", 2048)}, nil)
+	c.Assert(err, ErrorMatches, `Template "support": Live stream description exceeds maximum length .*`)
+
+	standup, _ := tmpls.Get("standup")
+
+	conf, err = standup.Render(nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(conf.LiveStream.Title, Equals, "Daily standup")
+	c.Assert(conf.WaitingRoomLevel.IsZero(), Equals, true)
+
+	conf, err = (&Template{Name: "empty"}).Render(nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(conf.LiveStream, IsNil)
+
+	api, _ := NewClient("Test1234")
+
+	info, err := api.CreateFromTemplate(tmpl, map[string]any{"ticket": 1, "customer": "ACME"}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "12345678901234")
+
+	_, err = api.CreateFromTemplate(nil, nil, nil)
+	c.Assert(err, Equals, ErrNilTemplate)
+
+	var nilClient *Client
+
+	_, err = nilClient.CreateFromTemplate(tmpl, nil, nil)
+	c.Assert(err, Equals, ErrNilClient)
+
+	tmpls, err = ParseTemplates([]byte(`{"test": {"title": "{{.name}}", "waiting_room_level": "ADMINS"}}`))
+	c.Assert(err, IsNil)
+	c.Assert(tmpls["test"].WaitingRoomLevel, Equals, ROOM_LEVEL_ADMINS)
+
+	_, err = ParseTemplates([]byte(`{"test": {"title": "{{.name"}}`))
+	c.Assert(err, ErrorMatches, `Can't parse title of template "test": .*`)
+	_, err = ParseTemplates([]byte(`{"test": {"waiting_room_level": "ALL"}}`))
+	c.Assert(err, ErrorMatches, `Template "test" has unknown waiting room level "ALL"`)
+	_, err = ParseTemplates([]byte(`{"test": {"access_level": "ALL"}}`))
+	c.Assert(err, ErrorMatches, `Template "test" has unknown live stream access level "ALL"`)
+	_, err = ParseTemplates([]byte(`{"test": null}`))
+	c.Assert(err, ErrorMatches, `Template "test" is empty`)
+	_, err = ParseTemplates([]byte(`[]`))
+	c.Assert(err, ErrorMatches, "Can't decode templates: .*")
+
+	_, err = LoadTemplates(dir + "/unknown.json")
+	c.Assert(err, ErrorMatches, "Can't read templates: .*")
+
+	_, err = (&Template{}).Render(nil, nil)
+	c.Assert(err, Equals, ErrEmptyTemplate)
+}
+
+func (s *TelemostSuite) TestGetCohosts(c *C) {
+	api, _ := NewClient("Test1234")
+	cohosts, err := api.GetCohosts("12345678901234")
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts, NotNil)
+	c.Assert(cohosts, HasLen, 2)
+
+	c.Assert(cohosts.Flatten(), DeepEquals, []string{"user1@yandex.ru", "user2@org-domain.ru"})
+
+	api, _ = NewClient("http-error")
+	_, err = api.GetCohosts("12345678901234")
+
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, `API returned error: Conference not found. (ConferenceNotFound)`)
+}
+
+func (s *TelemostSuite) TestCohostsPagination(c *C) {
+	var pages int
+
+	api, err := NewClient("Test1234", WithPageSize(2), WithRetryPolicy(RetryPolicy{
+		OnAttempt: func(a Attempt) { pages++ },
+	}))
+
+	c.Assert(err, IsNil)
+
+	cohosts, err := api.GetAllCohosts(context.Background(), "55555555555555")
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts.Flatten(), DeepEquals, []string{
+		"user1@domain.com", "user2@domain.com", "user3@domain.com",
+		"user4@domain.com", "user5@domain.com",
+	})
+	c.Assert(pages, Equals, 3)
+
+	pages = 0
+	cohosts, err = api.GetCohosts("55555555555555")
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts, HasLen, 5)
+	c.Assert(pages, Equals, 3)
+
+	pages = 0
+
+	var emails []string
+
+	for host, err := range api.CohostsIter(context.Background(), "55555555555555") {
+		c.Assert(err, IsNil)
+
+		emails = append(emails, host.Email)
+
+		if len(emails) == 3 {
+			break
+		}
+	}
+
+	c.Assert(emails, HasLen, 3)
+	c.Assert(pages, Equals, 2)
+
+	api, _ = NewClient("Test1234", WithPageSize(5))
+	cohosts, err = api.GetAllCohosts(context.Background(), "55555555555555")
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts, HasLen, 5)
+
+	api, _ = NewClient("http-error", WithPageSize(2))
+	_, err = api.GetAllCohosts(context.Background(), "55555555555555")
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+
+	// Cohosts added over creation limit are returned too
+	api, _ = NewClient("Test1234", WithPageSize(10))
+	cohosts, err = api.GetAllCohosts(context.Background(), "88888888888888")
+	c.Assert(err, IsNil)
+	c.Assert(cohosts, HasLen, 45)
+
+	// Server caps page size below configured one
+	pages = 0
+	api, _ = NewClient("Test1234", WithRetryPolicy(RetryPolicy{
+		OnAttempt: func(a Attempt) { pages++ },
+	}))
+	cohosts, err = api.GetAllCohosts(context.Background(), "44444444444444")
+	c.Assert(err, IsNil)
+	c.Assert(cohosts, HasLen, 250)
+	c.Assert(cohosts[249].Email, Equals, "user250@domain.com")
+	c.Assert(pages, Equals, 3)
+
+	api, _ = NewClient("Test1234", WithPageSize(1))
+	_, err = api.GetAllCohosts(context.Background(), "66666666666666")
+	c.Assert(err, ErrorMatches, `API returned more than 1000 pages of cohosts`)
+
+	api, _ = NewClient("Test1234", WithPageSize(4))
+	_, err = api.GetAllCohosts(context.Background(), "77777777777777")
+	c.Assert(err, ErrorMatches, `API returned the same page of cohosts twice \(offset: 4\)`)
+
+	_, err = api.GetAllCohosts(context.Background(), "")
+	c.Assert(err, Equals, ErrEmptyID)
+
+	var nilClient *Client
+
+	_, err = nilClient.GetAllCohosts(context.Background(), "55555555555555")
+	c.Assert(err, Equals, ErrNilClient)
+
+	_, err = NewClient("Test1234", WithPageSize(0))
+	c.Assert(err, ErrorMatches, `Page size must be greater than 0 \(0\)`)
+}
+
+func (s *TelemostSuite) TestSyncCohosts(c *C) {
+	var methods []string
+
+	api, _ := NewClient("Test1234", WithRetryPolicy(RetryPolicy{
+		OnAttempt: func(a Attempt) { methods = append(methods, a.Method) },
+	}))
+
+	plan, err := api.SyncCohosts("12345678901234", []string{" USER1@yandex.ru", "user2@org-domain.ru", ""})
+
+	c.Assert(err, IsNil)
+	c.Assert(plan.IsEmpty(), Equals, true)
+	c.Assert(methods, DeepEquals, []string{"GET", "GET"})
+
+	methods = nil
+	plan, err = api.PlanCohosts(context.Background(), "12345678901234", []string{"user1@yandex.ru", "user3@yandex.ru"})
+
+	c.Assert(err, IsNil)
+	c.Assert(plan.Add, DeepEquals, []string{"user3@yandex.ru"})
+	c.Assert(plan.Remove, DeepEquals, []string{"user2@org-domain.ru"})
+	c.Assert(methods, DeepEquals, []string{"GET", "GET"})
+
+	methods = nil
+	plan, err = api.SyncCohosts("12345678901234", []string{"user1@yandex.ru", "user2@org-domain.ru", "User3@Yandex.ru"})
+
+	c.Assert(err, IsNil)
+	c.Assert(plan.Add, DeepEquals, []string{"user3@yandex.ru"})
+	c.Assert(plan.Remove, HasLen, 0)
+	c.Assert(methods, DeepEquals, []string{"GET", "GET", "PATCH"})
+
+	methods = nil
+	plan, err = api.SyncCohosts("12345678901234", []string{"user1@yandex.ru", "user1@yandex.ru"})
+
+	c.Assert(err, IsNil)
+	c.Assert(plan.Add, HasLen, 0)
+	c.Assert(plan.Remove, DeepEquals, []string{"user2@org-domain.ru"})
+	c.Assert(methods, DeepEquals, []string{"GET", "GET", "DELETE"})
+
+	methods = nil
+	plan, err = api.SyncCohosts("12345678901234", []string{"user1@yandex.ru", "user3@yandex.ru"})
+
+	c.Assert(err, IsNil)
+	c.Assert(plan.Desired, DeepEquals, []string{"user1@yandex.ru", "user3@yandex.ru"})
+	c.Assert(methods, DeepEquals, []string{"GET", "GET", "PUT"})
+
+	var hosts []string
+
+	for i := range 31 {
+		hosts = append(hosts, "user"+strconv.Itoa(i)+"@yandex.ru")
+	}
+
+	_, err = api.SyncCohosts("12345678901234", hosts)
+	c.Assert(err, ErrorMatches, `Too many cohosts \(31 > 30\)`)
+
+	_, err = api.SyncCohosts("", hosts)
+	c.Assert(err, Equals, ErrEmptyID)
+
+	api, _ = NewClient("http-error")
+	_, err = api.SyncCohosts("12345678901234", nil)
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+
+	var nilClient *Client
+
+	_, err = nilClient.SyncCohosts("12345678901234", nil)
+	c.Assert(err, Equals, ErrNilClient)
+
+	var nilPlan *CohostsPlan
+	c.Assert(nilPlan.IsEmpty(), Equals, true)
+}
+
+func (s *TelemostSuite) TestAddCohosts(c *C) {
+	api, _ := NewClient("Test1234")
+	err := api.AddCohosts("12345678901234", []string{"user1@yandex.ru"})
+
+	c.Assert(err, IsNil)
+}
+
+func (s *TelemostSuite) TestUpdateCohosts(c *C) {
+	api, _ := NewClient("Test1234")
+	err := api.UpdateCohosts("12345678901234", []string{"user1@yandex.ru"})
+
+	c.Assert(err, IsNil)
+}
+
+func (s *TelemostSuite) TestDeleteCohosts(c *C) {
+	rt := &countingTransport{}
+	api, _ := NewClient("Test1234", WithTransport(rt))
+	err := api.DeleteCohosts("12345678901234", []string{"user1@yandex.ru"})
+
+	c.Assert(err, IsNil)
+	c.Assert(rt.query, Equals, "cohost_emails=user1%40yandex.ru")
+
+	err = api.DeleteCohosts("12345678901234", []string{"user1@yandex.ru", "user+2@yandex.ru"})
+
+	c.Assert(err, IsNil)
+	c.Assert(rt.query, Equals, "cohost_emails=user1%40yandex.ru,user%2B2%40yandex.ru")
+
+	c.Assert(encodeQuery(url.Values{"limit": {"10"}, "offset": {"0"}}), Equals, "limit=10&offset=0")
+	c.Assert(encodeQuery(nil), Equals, "")
+}
+
+func (s *TelemostSuite) TestContext(c *C) {
+	api, _ := NewClient("Test1234")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := api.GetContext(ctx, "12345678901234")
+	c.Assert(errors.Is(err, context.Canceled), Equals, true)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err = api.GetContext(ctx, "98765432109876")
+	c.Assert(errors.Is(err, context.Canceled), Equals, true)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = api.GetContext(ctx, "98765432109876")
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	err = api.DeleteContext(ctx, "12345678901234")
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	_, err = api.CreateContext(ctx, &Conference{})
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	_, err = api.UpdateContext(ctx, "12345678901234", &Conference{})
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	_, err = api.GetCohostsContext(ctx, "12345678901234")
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	err = api.AddCohostsContext(ctx, "12345678901234", []string{"user1@yandex.ru"})
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	err = api.UpdateCohostsContext(ctx, "12345678901234", []string{"user1@yandex.ru"})
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	err = api.DeleteCohostsContext(ctx, "12345678901234", []string{"user1@yandex.ru"})
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+}
+
+func (s *TelemostSuite) TestOptions(c *C) {
+	api1, err := NewClient("Test1234", WithBaseURL("http://127.0.0.1:"+TEST_PORT+"/"))
+	c.Assert(err, IsNil)
+	api2, err := NewClient("Test1234", WithBaseURL("http://127.0.0.1:9999"))
+	c.Assert(err, IsNil)
+
+	_, err = api1.Get("12345678901234")
+	c.Assert(err, IsNil)
+	_, err = api2.Get("12345678901234")
+	c.Assert(err, NotNil)
+
+	rt := &countingTransport{}
+	api, err := NewClient("Test1234", WithTransport(rt))
+	c.Assert(err, IsNil)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(rt.count.Load(), Equals, int32(1))
+
+	hc := &http.Client{Transport: rt}
+	api, err = NewClient("Test1234", WithHTTPClient(hc), WithTimeout(50*time.Millisecond))
+	c.Assert(err, IsNil)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(rt.count.Load(), Equals, int32(2))
+	c.Assert(hc.Timeout, Equals, time.Duration(0))
+	_, err = api.Get("98765432109876")
+	c.Assert(err, NotNil)
+
+	proxyURL, _ := url.Parse("http://127.0.0.1:" + TEST_PORT)
+	api, err = NewClient(
+		"Test1234", WithBaseURL("http://telemost.invalid"), WithProxy(proxyURL),
+	)
+	c.Assert(err, IsNil)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(handlerGetConference))
+	defer tlsServer.Close()
+
+	api, err = NewClient("Test1234", WithBaseURL(tlsServer.URL))
+	c.Assert(err, IsNil)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, NotNil)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(tlsServer.Certificate())
+
+	api, err = NewClient(
+		"Test1234", WithBaseURL(tlsServer.URL),
+		WithTLSConfig(&tls.Config{RootCAs: pool}),
+	)
+	c.Assert(err, IsNil)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+
+	_, err = NewClient("Test1234", WithBaseURL(""))
+	c.Assert(err, Equals, ErrEmptyBaseURL)
+	_, err = NewClient("Test1234", WithBaseURL("ftp://telemost"))
+	c.Assert(err, ErrorMatches, `Unsupported scheme in base URL "ftp://telemost"`)
+	_, err = NewClient("Test1234", WithHTTPClient(nil))
+	c.Assert(err, Equals, ErrNilHTTPClient)
+	_, err = NewClient("Test1234", WithTransport(nil))
+	c.Assert(err, Equals, ErrNilTransport)
+	_, err = NewClient("Test1234", WithTimeout(-time.Second))
+	c.Assert(err, ErrorMatches, `Timeout can't be negative \(-1s\)`)
+	_, err = NewClient("Test1234", WithProxy(nil))
+	c.Assert(err, Equals, ErrNilProxy)
+	_, err = NewClient("Test1234", WithTLSConfig(nil))
+	c.Assert(err, Equals, ErrNilTLSConfig)
+	_, err = NewClient("Test1234", WithProxy(proxyURL), WithTransport(rt))
+	c.Assert(err, Equals, ErrIncompatibleOpts)
+	_, err = NewClient("Test1234", nil)
+	c.Assert(err, IsNil)
+}
+
+func (s *TelemostSuite) TestAPIError(c *C) {
+	api, _ := NewClient("http-error")
+	_, err := api.Get("12345678901234")
+
+	var apiErr *APIError
+
+	c.Assert(errors.As(err, &apiErr), Equals, true)
+	c.Assert(apiErr.StatusCode, Equals, 404)
+	c.Assert(apiErr.Code, Equals, "ConferenceNotFound")
+	c.Assert(apiErr.Description, Equals, "Conference not found.")
+	c.Assert(apiErr.Message, Equals, "Видео встреча не найдена.")
+	c.Assert(apiErr.RequestID, Equals, "")
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+	c.Assert(errors.Is(err, ErrUnauthorized), Equals, false)
+
+	for code, sentinel := range map[int]error{
+		401: ErrUnauthorized,
+		403: ErrForbidden,
+		404: ErrNotFound,
+		409: ErrConflict,
+		429: ErrRateLimited,
+	} {
+		api, _ = NewClient("status-" + strconv.Itoa(code))
+		_, err = api.Get("12345678901234")
+
+		c.Assert(errors.Is(err, sentinel), Equals, true)
+		c.Assert(errors.As(err, &apiErr), Equals, true)
+		c.Assert(apiErr.StatusCode, Equals, code)
+		c.Assert(apiErr.RequestID, Equals, "abcd1234")
+		c.Assert(apiErr.Header.Get("X-Request-Id"), Equals, "abcd1234")
+	}
+
+	api, _ = NewClient("msg-error")
+	_, err = api.Get("12345678901234")
+
+	c.Assert(errors.As(err, &apiErr), Equals, true)
+	c.Assert(apiErr.StatusCode, Equals, 404)
+	c.Assert(apiErr.Code, Equals, "")
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+}
+
+func (s *TelemostSuite) TestRetries(c *C) {
+	var attempts []Attempt
+
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		MinDelay:    10 * time.Millisecond,
+		MaxDelay:    20 * time.Millisecond,
+		Jitter:      0.5,
+		OnAttempt:   func(a Attempt) { attempts = append(attempts, a) },
+	}
+
+	api, err := NewClient(
+		"flaky-503", WithBaseURL(API+"/flaky"), WithRetryPolicy(policy),
+	)
+
+	c.Assert(err, IsNil)
+
+	resetFlaky(2)
+	info, err := api.Get("12345678901234")
+
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "12345678901234")
+	c.Assert(attempts, HasLen, 3)
+	c.Assert(attempts[0].Num, Equals, 1)
+	c.Assert(attempts[0].Method, Equals, "GET")
+	c.Assert(attempts[0].Endpoint, Equals, "/12345678901234")
+	c.Assert(attempts[0].StatusCode, Equals, 503)
+	c.Assert(attempts[0].Delay > 0, Equals, true)
+	c.Assert(attempts[2].StatusCode, Equals, 0)
+	c.Assert(attempts[2].Err, IsNil)
+	c.Assert(attempts[2].Delay, Equals, time.Duration(0))
+
+	attempts = nil
+	resetFlaky(5)
+	_, err = api.Get("12345678901234")
+
+	c.Assert(getStatusCode(err), Equals, 503)
+	c.Assert(attempts, HasLen, 3)
+
+	attempts = nil
+	resetFlaky(1)
+	_, err = api.Create(&Conference{})
+
+	c.Assert(getStatusCode(err), Equals, 503)
+	c.Assert(attempts, HasLen, 1)
+
+	policy.Methods = []string{"GET", "POST"}
+	api, _ = NewClient("flaky-503", WithBaseURL(API+"/flaky"), WithRetryPolicy(policy))
+
+	attempts = nil
+	resetFlaky(1)
+	_, err = api.Create(&Conference{})
+
+	c.Assert(err, IsNil)
+	c.Assert(attempts, HasLen, 2)
+
+	api, _ = NewClient("flaky-429", WithBaseURL(API+"/flaky"), WithRetryPolicy(policy))
+
+	attempts = nil
+	resetFlaky(1)
+	_, err = api.Get("12345678901234")
+
+	c.Assert(err, IsNil)
+	c.Assert(attempts, HasLen, 2)
+	c.Assert(attempts[0].StatusCode, Equals, 429)
+	c.Assert(attempts[0].Delay, Equals, time.Second)
+
+	api, _ = NewClient("flaky-400", WithBaseURL(API+"/flaky"), WithRetryPolicy(policy))
+
+	attempts = nil
+	resetFlaky(1)
+	_, err = api.Get("12345678901234")
+
+	c.Assert(getStatusCode(err), Equals, 400)
+	c.Assert(attempts, HasLen, 1)
+
+	api, _ = NewClient("flaky-429", WithBaseURL(API+"/flaky"), WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	attempts = nil
+	resetFlaky(1)
+	_, err = api.GetContext(ctx, "12345678901234")
+
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(attempts, HasLen, 1)
+
+	api, _ = NewClient("Test1234", WithBaseURL("http://127.0.0.1:9999"), WithRetryPolicy(policy))
+
+	attempts = nil
+	_, err = api.Get("12345678901234")
+
+	c.Assert(err, NotNil)
+	c.Assert(attempts, HasLen, 3)
+}
+
+func (s *TelemostSuite) TestRetryPolicy(c *C) {
+	c.Assert(DefaultRetryPolicy.Validate(), IsNil)
+
+	c.Assert(RetryPolicy{MaxAttempts: -1}.Validate(), ErrorMatches, `Maximum number of attempts can't be negative \(-1\)`)
+	c.Assert(RetryPolicy{MinDelay: -time.Second}.Validate(), ErrorMatches, `Minimal delay can't be negative \(-1s\)`)
+	c.Assert(RetryPolicy{MaxDelay: -time.Second}.Validate(), ErrorMatches, `Maximum delay can't be negative \(-1s\)`)
+	c.Assert(RetryPolicy{MinDelay: time.Minute, MaxDelay: time.Second}.Validate(), ErrorMatches, `Maximum delay is less than minimal delay \(1s < 1m0s\)`)
+	c.Assert(RetryPolicy{Jitter: 2}.Validate(), ErrorMatches, `Jitter must be in range 0-1 \(2\)`)
+
+	_, err := NewClient("Test1234", WithRetryPolicy(RetryPolicy{Jitter: 2}))
+	c.Assert(err, NotNil)
+
+	p := RetryPolicy{MaxAttempts: 10, MinDelay: time.Second, MaxDelay: 5 * time.Second}
+	apiErr := &APIError{StatusCode: 503, Header: http.Header{}}
+
+	d, ok := p.next("GET", 1, apiErr)
+	c.Assert(ok, Equals, true)
+	c.Assert(d, Equals, time.Second)
+	d, _ = p.next("GET", 3, apiErr)
+	c.Assert(d, Equals, 4*time.Second)
+	d, _ = p.next("GET", 8, apiErr)
+	c.Assert(d, Equals, 5*time.Second)
+
+	jp := RetryPolicy{MaxAttempts: 10, MinDelay: time.Second, MaxDelay: 5 * time.Second, Jitter: 1}
+
+	for range 100 {
+		d, _ = jp.next("GET", 8, apiErr)
+		c.Assert(d <= 5*time.Second, Equals, true)
+	}
+
+	_, ok = p.next("GET", 10, apiErr)
+	c.Assert(ok, Equals, false)
+	_, ok = p.next("POST", 1, apiErr)
+	c.Assert(ok, Equals, false)
+	_, ok = p.next("GET", 1, nil)
+	c.Assert(ok, Equals, false)
+	_, ok = p.next("GET", 1, ErrNotFound)
+	c.Assert(ok, Equals, false)
+
+	apiErr.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	d, _ = p.next("GET", 1, apiErr)
+	c.Assert(d > 50*time.Second, Equals, true)
+	d, _ = jp.next("GET", 8, apiErr)
+	c.Assert(d > 50*time.Second, Equals, true)
+
+	apiErr.Header.Set("Retry-After", "abcd")
+	d, _ = p.next("GET", 1, apiErr)
+	c.Assert(d, Equals, time.Second)
+}
+
+func (s *TelemostSuite) TestLimiter(c *C) {
+	c.Assert(NewLimiter(0, 1), IsNil)
+
+	var nilLimiter *Limiter
+
+	c.Assert(nilLimiter.Wait(context.Background()), IsNil)
+	c.Assert(nilLimiter.Stats(), DeepEquals, LimiterStats{})
+
+	limiter := NewLimiter(20, 2)
+	api1, _ := NewClient("Test1234", WithLimiter(limiter))
+	api2, _ := NewClient("Test1234", WithLimiter(limiter))
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+
+	for i := range 6 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if i%2 == 0 {
+				api1.Get("12345678901234")
+			} else {
+				api2.Get("12345678901234")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	c.Assert(time.Since(start) >= 190*time.Millisecond, Equals, true)
+
+	stats := limiter.Stats()
+
+	c.Assert(stats.Requests, Equals, int64(6))
+	c.Assert(stats.Throttled, Equals, int64(4))
+	c.Assert(stats.WaitTime > 0, Equals, true)
+
+	limiter = NewLimiter(1, 1)
+	api, _ := NewClient("Test1234", WithLimiter(limiter))
+
+	_, err := api.Get("12345678901234")
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = api.GetContext(ctx, "12345678901234")
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(limiter.Stats().Throttled, Equals, int64(1))
+
+	_, err = NewClient("Test1234", WithLimiter(nil))
+	c.Assert(err, Equals, ErrNilLimiter)
+}
+
+func (s *TelemostSuite) TestLimiterLatency(c *C) {
+	metrics := &recordingMetrics{}
+	api, _ := NewClient("Test1234", WithLimiter(NewLimiter(5, 1)), WithMetrics(metrics))
+
+	_, err := api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+
+	c.Assert(metrics.requests, HasLen, 2)
+	c.Assert(metrics.waits, HasLen, 2)
+	c.Assert(metrics.waits[1] >= 150*time.Millisecond, Equals, true)
+	c.Assert(metrics.requests[1] < 100*time.Millisecond, Equals, true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = api.GetContext(ctx, "12345678901234")
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(metrics.requests, HasLen, 2)
+	c.Assert(metrics.waits, HasLen, 3)
+}
+
+func (s *TelemostSuite) TestLogger(c *C) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	api, err := NewClient("Test1234", WithLogger(logger))
+
+	c.Assert(err, IsNil)
+
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+
+	err = api.DeleteCohosts("12345678901234", []string{"john.doe@yandex.ru", "bob@domain.com"})
+	c.Assert(err, IsNil)
+
+	_, err = api.Create(&Conference{CoHosts: Hosts{{Email: "alice@yandex.ru"}}})
+	c.Assert(err, IsNil)
+
+	logs := buf.String()
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+	c.Assert(logs, Matches, `(?s).*"msg":"API request completed","method":"GET","endpoint":"/12345678901234","status":200,"latency":\d+,"attempt":1.*`)
+	c.Assert(logs, Matches, `(?s).*"msg":"API response","status":200.*"body":"\{.*`)
+	c.Assert(logs, Matches, `(?s).*"Authorization":"\[REDACTED\]".*`)
+	c.Assert(logs, Matches, `(?s).*cohost_emails=\[REDACTED\]@yandex.ru,\[REDACTED\]@domain.com.*`)
+	c.Assert(logs, Matches, `(?s).*\[REDACTED\]@yandex.ru.*`)
+	c.Assert(strings.Contains(logs, "Test1234"), Equals, false)
+	c.Assert(strings.Contains(logs, "john.doe"), Equals, false)
+	c.Assert(strings.Contains(logs, "alice"), Equals, false)
 
-var _ = Suite(&TelemostSuite{})
+	buf.Reset()
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+	logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	api, _ = NewClient("status-404", WithLogger(logger))
 
-func (s *TelemostSuite) SetUpSuite(c *C) {
-	API = "http://127.0.0.1:" + TEST_PORT
+	_, err = api.Get("12345678901234")
+	c.Assert(err, NotNil)
 
-	mux := http.NewServeMux()
-	server := &http.Server{Addr: ":" + TEST_PORT, Handler: mux}
+	logs = buf.String()
 
-	mux.HandleFunc("POST /", handlerCreateConference)
-	mux.HandleFunc("GET /12345678901234", handlerGetConference)
-	mux.HandleFunc("PATCH /12345678901234", handlerUpdateConference)
-	mux.HandleFunc("DELETE /12345678901234", handlerDeleteConference)
-	mux.HandleFunc("GET /12345678901234/cohosts", handlerGetCohosts)
-	mux.HandleFunc("PATCH /12345678901234/cohosts", handlerAddCohosts)
-	mux.HandleFunc("PUT /12345678901234/cohosts", handlerUpdateCohosts)
-	mux.HandleFunc("DELETE /12345678901234/cohosts", handlerDeleteCohosts)
+	c.Assert(logs, Matches, `(?s).*"level":"WARN","msg":"API request failed","method":"GET","endpoint":"/12345678901234","status":404,.*"error_code":"Error404","request_id":"abcd1234".*`)
+	c.Assert(strings.Contains(logs, `"msg":"API response"`), Equals, false)
 
-	go server.ListenAndServe()
+	_, err = NewClient("Test1234", WithLogger(nil))
+	c.Assert(err, Equals, ErrNilLogger)
 
-	time.Sleep(time.Second)
+	c.Assert(redactEmails("user@yandex.ru, test"), Equals, "[REDACTED]@yandex.ru, test")
+	c.Assert(redactHeaders(http.Header{"Cookie": {"a=1"}, "Accept": {"a", "b"}}), DeepEquals, map[string]string{"Cookie": REDACTED, "Accept": "a, b"})
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+func (s *TelemostSuite) TestTracing(c *C) {
+	tracer := &recordingTracer{}
+	rt := &countingTransport{}
 
-func (s *TelemostSuite) TestConferenceValidation(c *C) {
-	conf := &Conference{WaitingRoomLevel: "TEST"}
-	c.Assert(validateConference(conf).Error(), Equals, `Unknown waiting room level "TEST"`)
+	api, err := NewClient("Test1234", WithTracer(tracer), WithTransport(rt))
+	c.Assert(err, IsNil)
 
-	conf = &Conference{LiveStream: &LiveStream{AccessLevel: "TEST"}}
-	c.Assert(validateConference(conf).Error(), Equals, `Unknown live stream access level "TEST"`)
+	err = api.AddCohosts("12345678901234", []string{"user1@yandex.ru", "user2@yandex.ru"})
+	c.Assert(err, IsNil)
 
-	conf = &Conference{LiveStream: &LiveStream{AccessLevel: "PUBLIC", Title: strings.Repeat("TEST1234", 180)}}
-	c.Assert(validateConference(conf).Error(), Equals, `Live stream title exceeds maximum length (1440 > 1024)`)
+	c.Assert(tracer.spans, DeepEquals, []string{
+		"start AddCohosts 12345678901234 2",
+		"start PATCH /12345678901234/cohosts 1",
+		"end 204 <nil>",
+		"end 0 <nil>",
+	})
 
-	conf = &Conference{LiveStream: &LiveStream{AccessLevel: "PUBLIC", Description: strings.Repeat("TEST1234", 300)}}
-	c.Assert(validateConference(conf).Error(), Equals, `Live stream description exceeds maximum length (2400 > 2048)`)
+	c.Assert(rt.header.Get("X-Trace"), Equals, "PATCH /12345678901234/cohosts 1")
 
-	var hosts Hosts
+	tracer.spans = nil
 
-	for range 50 {
-		hosts = append(hosts, &Host{`test@test.com`})
-	}
+	_, err = api.Create(&Conference{})
+	c.Assert(err, IsNil)
 
-	conf = &Conference{CoHosts: hosts}
-	c.Assert(validateConference(conf).Error(), Equals, `Too many cohosts (50 > 30)`)
-}
+	c.Assert(tracer.spans, DeepEquals, []string{
+		"start Create  0",
+		"start POST  1",
+		"end 200 <nil>",
+		"id 12345678901234",
+		"end 0 <nil>",
+	})
 
-func (s *TelemostSuite) TestGet(c *C) {
-	api, _ := NewClient("Test1234")
-	info, err := api.Get("12345678901234")
+	tracer.spans = nil
 
-	api.SetUserAgent("Test", "1.2.3")
+	_, err = api.GetCohosts("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(tracer.spans[len(tracer.spans)-2:], DeepEquals, []string{
+		"cohosts 2", "end 0 <nil>",
+	})
+
+	api, _ = NewClient("status-404", WithTracer(tracer))
+	tracer.spans = nil
+
+	_, err = api.Get("12345678901234")
+	c.Assert(err, NotNil)
+	c.Assert(tracer.spans, HasLen, 4)
+	c.Assert(tracer.spans[2], Matches, `end 404 .+`)
+	c.Assert(tracer.spans[3], Matches, `end 0 .+`)
 
+	api, _ = NewClient("Test1234")
+
+	_, err = api.Get("12345678901234")
 	c.Assert(err, IsNil)
-	c.Assert(info, NotNil)
 
-	c.Assert(info.ID, Equals, "12345678901234")
-	c.Assert(info.WaitingRoomLevel, Equals, "ORGANIZATION")
-	c.Assert(info.LiveStream.WatchURL, Equals, "https://telemost.yandex.ru/live/123456789abcdef0123456789abcdef0")
-	c.Assert(info.LiveStream.AccessLevel, Equals, "PUBLIC")
-	c.Assert(info.LiveStream.Title, Equals, "Example conference created via API")
-	c.Assert(info.LiveStream.Description, Equals, "Some description of example conference created via API")
-	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/12345678901234")
-	c.Assert(info.SIPURIMeeting, Equals, "12345678901234567890@sip.t.ya.ru")
-	c.Assert(info.SIPURITelemost, Equals, "j@sip.t.ya.ru")
-	c.Assert(info.SIPID, Equals, "12345678901234567890")
+	_, err = NewClient("Test1234", WithTracer(nil))
+	c.Assert(err, Equals, ErrNilTracer)
 }
 
-func (s *TelemostSuite) TestCreate(c *C) {
-	conf := &Conference{}
-
+func (s *TelemostSuite) TestMiddleware(c *C) {
 	api, _ := NewClient("Test1234")
-	info, err := api.Create(conf.WithCohosts("user@domain.com"))
 
+	var calls []string
+
+	api.Use(
+		func(next Handler) Handler {
+			return func(ctx context.Context, r *Request) error {
+				calls = append(calls, "1:"+r.Operation)
+				err := next(ctx, r)
+				calls = append(calls, "1:done")
+				return err
+			}
+		},
+		nil,
+		func(next Handler) Handler {
+			return func(ctx context.Context, r *Request) error {
+				calls = append(calls, "2:"+r.Method+" "+r.Endpoint)
+				err := next(ctx, r)
+
+				if info, ok := r.Response.(*ConferenceInfo); ok && err == nil {
+					calls = append(calls, "2:"+info.ID)
+				}
+
+				return err
+			}
+		},
+	)
+
+	_, err := api.Get("12345678901234")
 	c.Assert(err, IsNil)
-	c.Assert(info, NotNil)
+	c.Assert(calls, DeepEquals, []string{
+		"1:Get", "2:GET /12345678901234", "2:12345678901234", "1:done",
+	})
 
-	c.Assert(info.ID, Equals, "12345678901234")
-	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/12345678901234")
-	c.Assert(info.LiveStream.WatchURL, Equals, "https://telemost.yandex.ru/live/123456789abcdef0123456789abcdef0")
+	var req *Request
 
-	_, err = api.Create(&Conference{WaitingRoomLevel: "TEST"})
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, `Unknown waiting room level "TEST"`)
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			req = r
+			return next(ctx, r)
+		}
+	})
+
+	err = api.DeleteCohosts("12345678901234", []string{"user1@yandex.ru", "user2@yandex.ru"})
+	c.Assert(err, IsNil)
+	c.Assert(req.Operation, Equals, OP_DELETE_COHOSTS)
+	c.Assert(req.ID, Equals, "12345678901234")
+	c.Assert(req.Query["cohost_emails"], DeepEquals, []string{"user1@yandex.ru", "user2@yandex.ru"})
+
+	err = api.AddCohosts("12345678901234", []string{"user1@yandex.ru"})
+	c.Assert(err, IsNil)
+	c.Assert(req.Payload, NotNil)
 
 	api, _ = NewClient("http-error")
-	_, err = api.Create(&Conference{})
 
+	var apiErr error
+
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			apiErr = next(ctx, r)
+			return apiErr
+		}
+	})
+
+	_, err = api.Get("12345678901234")
 	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, `API returned error: Conference not found. (ConferenceNotFound)`)
+	c.Assert(errors.Is(apiErr, ErrNotFound), Equals, true)
+
+	api, _ = NewClient("Test1234", WithBaseURL("http://127.0.0.1:1"))
+
+	api.Use(func(next Handler) Handler {
+		return func(ctx context.Context, r *Request) error {
+			if r.Operation == OP_DELETE {
+				return fmt.Errorf("Deletion is forbidden by policy")
+			}
+
+			if info, ok := r.Response.(*ConferenceInfo); ok {
+				info.ID = r.ID
+				return nil
+			}
+
+			return next(ctx, r)
+		}
+	})
+
+	info, err := api.Get("1234")
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "1234")
+
+	err = api.Delete("1234")
+	c.Assert(err, ErrorMatches, "Deletion is forbidden by policy")
+
+	var nilClient *Client
+	nilClient.Use(nil)
 }
 
-func (s *TelemostSuite) TestUpdate(c *C) {
-	api, _ := NewClient("Test1234")
-	info, err := api.Update("12345678901234", &Conference{LiveStream: &LiveStream{
-		AccessLevel: "PUBLIC",
-		Title:       "Example conference created via API",
-		Description: "Some description of example conference created via API",
-	}})
+func (s *TelemostSuite) TestTokenSources(c *C) {
+	ctx := context.Background()
+
+	_, err := NewClientWithTokenSource(nil)
+	c.Assert(err, Equals, ErrNilTokenSource)
+
+	_, err = StaticToken("").Token(ctx)
+	c.Assert(err, Equals, ErrEmptyToken)
+
+	_, err = EnvToken("").Token(ctx)
+	c.Assert(err, Equals, ErrEmptyVarName)
+
+	os.Setenv("TELEMOST_TEST_TOKEN", "")
+	_, err = EnvToken("TELEMOST_TEST_TOKEN").Token(ctx)
+	c.Assert(err, ErrorMatches, `Environment variable TELEMOST_TEST_TOKEN is empty`)
+
+	api, _ := NewClientWithTokenSource(EnvToken("TELEMOST_TEST_TOKEN"))
+	_, err = api.Get("12345678901234")
+	c.Assert(err, ErrorMatches, `Can't get token: Environment variable TELEMOST_TEST_TOKEN is empty`)
 
+	os.Setenv("TELEMOST_TEST_TOKEN", "Test1234")
+	_, err = api.Get("12345678901234")
 	c.Assert(err, IsNil)
-	c.Assert(info, NotNil)
 
-	c.Assert(info.LiveStream.AccessLevel, Equals, "PUBLIC")
-	c.Assert(info.LiveStream.Title, Equals, "Example conference created via API")
-	c.Assert(info.LiveStream.Description, Equals, "Some description of example conference created via API")
+	os.Setenv("TELEMOST_TEST_TOKEN", "http-error")
+	_, err = api.Get("12345678901234")
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
 
-	_, err = api.Update("12345678901234", &Conference{WaitingRoomLevel: "TEST"})
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, `Unknown waiting room level "TEST"`)
+	tokenFile := filepath.Join(c.MkDir(), "token")
+	ft := NewFileToken(tokenFile)
+	api, _ = NewClientWithTokenSource(ft)
 
-	api, _ = NewClient("http-error")
-	_, err = api.Update("12345678901234", &Conference{})
+	_, err = api.Get("12345678901234")
+	c.Assert(err, ErrorMatches, `Can't get token: Can't read token file: .*`)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, `API returned error: Conference not found. (ConferenceNotFound)`)
+	os.WriteFile(tokenFile, []byte("  \n"), 0600)
+	_, err = ft.Token(ctx)
+	c.Assert(err, ErrorMatches, `Token file .* is empty`)
+
+	os.WriteFile(tokenFile, []byte("Test1234\n"), 0600)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
 
+	os.WriteFile(tokenFile, []byte("http-error\n"), 0600)
+	os.Chtimes(tokenFile, time.Now(), time.Now().Add(time.Minute))
+	_, err = api.Get("12345678901234")
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+
+	_, err = NewFileToken("").Token(ctx)
+	c.Assert(err, Equals, ErrEmptyPath)
 }
 
-func (s *TelemostSuite) TestDelete(c *C) {
-	api, _ := NewClient("Test1234")
-	err := api.Delete("12345678901234")
+func (s *TelemostSuite) TestOAuthToken(c *C) {
+	var refreshes atomic.Int32
+
+	oauthServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		switch {
+		case r.PostForm.Get("grant_type") != "refresh_token",
+			r.PostForm.Get("client_id") != "app-id",
+			r.PostForm.Get("client_secret") != "app-secret":
+			rw.WriteHeader(400)
+			rw.Write([]byte(`{"error": "invalid_client", "error_description": "Client not found"}`))
+		case r.PostForm.Get("refresh_token") == "broken":
+			rw.WriteHeader(500)
+			rw.Write([]byte(`XXX`))
+		default:
+			refreshes.Add(1)
+			rw.Write([]byte(`{"access_token": "Test1234", "refresh_token": "r` +
+				strconv.Itoa(int(refreshes.Load())) + `", "expires_in": 3600}`))
+		}
+	}))
+
+	defer oauthServer.Close()
+
+	var refreshToken string
+
+	ts := NewOAuthToken("app-id", "app-secret", "expired-token", "r0", time.Time{})
+	ts.URL = oauthServer.URL
+	src := ts
+	ts.OnRefresh = func(_, refresh string, _ time.Time) {
+		// Callback must be able to use token source without deadlock
+		token, _ := src.Token(context.Background())
+		c.Check(token, Equals, "Test1234")
+		refreshToken = refresh
+	}
+
+	var attempts []Attempt
+
+	api, _ := NewClientWithTokenSource(ts, WithRetryPolicy(RetryPolicy{
+		OnAttempt: func(a Attempt) { attempts = append(attempts, a) },
+	}))
+
+	info, err := api.Get("12345678901234")
 
 	c.Assert(err, IsNil)
-}
+	c.Assert(info.ID, Equals, "12345678901234")
+	c.Assert(refreshes.Load(), Equals, int32(1))
+	c.Assert(refreshToken, Equals, "r1")
+	c.Assert(attempts, HasLen, 2)
+	c.Assert(attempts[0].Num, Equals, 1)
+	c.Assert(attempts[0].StatusCode, Equals, 401)
+	c.Assert(errors.Is(attempts[0].Err, ErrUnauthorized), Equals, true)
+	c.Assert(attempts[1].Num, Equals, 2)
+	c.Assert(attempts[1].Err, IsNil)
 
-func (s *TelemostSuite) TestGetCohosts(c *C) {
-	api, _ := NewClient("Test1234")
-	cohosts, err := api.GetCohosts("12345678901234")
+	_, err = api.Get("12345678901234")
+	c.Assert(err, IsNil)
+	c.Assert(refreshes.Load(), Equals, int32(1))
+
+	// Concurrent requests rejected with the same token cause only one refresh
+	ts = NewOAuthToken("app-id", "app-secret", "expired-token", "r0", time.Time{})
+	ts.URL = oauthServer.URL
+	api, _ = NewClientWithTokenSource(ts)
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
 
+		go func() {
+			defer wg.Done()
+			_, err := api.Get("12345678901234")
+			c.Check(err, IsNil)
+		}()
+	}
+
+	wg.Wait()
+
+	c.Assert(refreshes.Load(), Equals, int32(2))
+	c.Assert(ts.Refresh(context.Background(), "expired-token"), IsNil)
+	c.Assert(refreshes.Load(), Equals, int32(2))
+
+	ts = NewOAuthToken("app-id", "app-secret", "", "r0", time.Time{})
+	ts.URL = oauthServer.URL
+
+	token, err := ts.Token(context.Background())
 	c.Assert(err, IsNil)
-	c.Assert(cohosts, NotNil)
-	c.Assert(cohosts, HasLen, 2)
+	c.Assert(token, Equals, "Test1234")
+	c.Assert(refreshes.Load(), Equals, int32(3))
 
-	c.Assert(cohosts.Flatten(), DeepEquals, []string{"user1@yandex.ru", "user2@org-domain.ru"})
+	ts = NewOAuthToken("app-id", "app-secret", "expired-token", "r0", time.Now().Add(time.Hour))
+	ts.URL = oauthServer.URL + "/unknown"
+	ts.ClientSecret = "wrong"
 
-	api, _ = NewClient("http-error")
-	_, err = api.GetCohosts("12345678901234")
+	api, _ = NewClientWithTokenSource(ts)
+	_, err = api.Get("12345678901234")
+	c.Assert(err, ErrorMatches, `Can't refresh token: OAuth server returned error: Client not found \(invalid_client\)`)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, `API returned error: Conference not found. (ConferenceNotFound)`)
+	ts = NewOAuthToken("app-id", "app-secret", "", "broken", time.Time{})
+	ts.URL = oauthServer.URL
+	_, err = ts.Token(context.Background())
+	c.Assert(err, ErrorMatches, `OAuth server returned non-ok status code 500`)
+
+	ts = NewOAuthToken("app-id", "app-secret", "", "r0", time.Time{})
+	ts.URL = "http://127.0.0.1:9999"
+	_, err = ts.Token(context.Background())
+	c.Assert(err, ErrorMatches, `Can't send token request: .*`)
+
+	_, err = NewOAuthToken("app-id", "app-secret", "", "", time.Time{}).Token(context.Background())
+	c.Assert(err, Equals, ErrEmptyRefresh)
+	_, err = NewOAuthToken("", "", "", "r0", time.Time{}).Token(context.Background())
+	c.Assert(err, Equals, ErrEmptyCredentials)
+
+	var nilTS *OAuthToken
+
+	_, err = nilTS.Token(context.Background())
+	c.Assert(err, Equals, ErrNilTokenSource)
+	c.Assert(nilTS.Refresh(context.Background(), ""), Equals, ErrNilTokenSource)
 }
 
-func (s *TelemostSuite) TestAddCohosts(c *C) {
-	api, _ := NewClient("Test1234")
-	err := api.AddCohosts("12345678901234", []string{"user1@yandex.ru"})
+func (s *TelemostSuite) TestICS(c *C) {
+	info := &ConferenceInfo{
+		Conference: Conference{
+			LiveStream: &LiveStream{WatchURL: "https://telemost.yandex.ru/live/abcd"},
+			CoHosts:    Hosts{{Email: "user1@yandex.ru"}, nil, {Email: "user2@yandex.ru"}},
+		},
+		ID:             "12345678901234",
+		JoinURL:        "https://telemost.yandex.ru/j/12345678901234",
+		SIPURIMeeting:  "12345678901234@sip.t.ya.ru",
+		SIPURITelemost: "12345678901234@sip.telemost.yandex.ru",
+		SIPID:          "12345678901234000000",
+	}
+
+	start := time.Date(2025, 3, 1, 15, 0, 0, 0, time.FixedZone("MSK", 3*3600))
+
+	event := &Event{
+		Summary:       "Weekly sync; team, all",
+		Description:   "Agenda:\nStatus\\updates",
+		Start:         start,
+		End:           start.Add(time.Hour),
+		Stamp:         time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC),
+		Organizer:     "boss@yandex.ru",
+		OrganizerName: "Boss, Big",
+	}
+
+	data, err := info.ICS(event, ICS_METHOD_REQUEST)
 
 	c.Assert(err, IsNil)
-}
 
-func (s *TelemostSuite) TestUpdateCohosts(c *C) {
-	api, _ := NewClient("Test1234")
-	err := api.UpdateCohosts("12345678901234", []string{"user1@yandex.ru"})
+	ics := string(data)
+
+	c.Assert(strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"), Equals, true)
+	c.Assert(strings.HasSuffix(ics, "END:VEVENT\r\nEND:VCALENDAR\r\n"), Equals, true)
+	c.Assert(ics, Matches, `(?s).*\r\nMETHOD:REQUEST\r\n.*`)
+	c.Assert(ics, Matches, `(?s).*\r\nUID:12345678901234@telemost.yandex.ru\r\n.*`)
+	c.Assert(ics, Matches, `(?s).*\r\nDTSTAMP:20250201T100000Z\r\n.*`)
+	c.Assert(ics, Matches, `(?s).*\r\nDTSTART:20250301T120000Z\r\nDTEND:20250301T130000Z\r\n.*`)
+	c.Assert(ics, Matches, `(?s).*\r\nSTATUS:CONFIRMED\r\n.*`)
+	c.Assert(ics, Matches, `(?s).*\r\nSUMMARY:Weekly sync\\; team\\, all\r\n.*`)
+	c.Assert(ics, Matches, `(?s).*\r\nLOCATION:https://telemost.yandex.ru/j/12345678901234\r\n.*`)
+	c.Assert(ics, Matches, `(?s).*\r\nORGANIZER;CN="Boss, Big":mailto:boss@yandex.ru\r\n.*`)
+	c.Assert(strings.Count(ics, "ATTENDEE;"), Equals, 2)
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		c.Assert(len(line) <= ICS_MAX_LINE_SIZE, Equals, true, Commentf("Line %q is too long", line))
+	}
+
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+
+	c.Assert(unfolded, Matches, `(?s).*\r\nDESCRIPTION:Agenda:\\nStatus\\\\updates\\n\\nJoin URL: .*\\nLive stream: https://telemost.yandex.ru/live/abcd\r\n.*`)
+	c.Assert(unfolded, Matches, `(?s).*SIP URI \(Telemost\): 12345678901234@sip.telemost.yandex.ru.*`)
+
+	event.Sequence = 1
+	data, err = info.ICS(event, ICS_METHOD_CANCEL)
 
 	c.Assert(err, IsNil)
-}
+	c.Assert(string(data), Matches, `(?s).*\r\nMETHOD:CANCEL\r\n.*\r\nSEQUENCE:1\r\nSTATUS:CANCELLED\r\n.*`)
 
-func (s *TelemostSuite) TestDeleteCohosts(c *C) {
-	api, _ := NewClient("Test1234")
-	err := api.DeleteCohosts("12345678901234", []string{"user1@yandex.ru"})
+	long := strings.Repeat("тест", 40)
+	data, err = info.ICS(&Event{UID: "test", Summary: long, Start: start, End: start, Organizer: "boss@yandex.ru"}, ICS_METHOD_REQUEST)
 
 	c.Assert(err, IsNil)
+	c.Assert(strings.ReplaceAll(string(data), "\r\n ", ""), Matches, `(?s).*\r\nSUMMARY:`+long+`\r\n.*`)
+	c.Assert(string(data), Matches, `(?s).*\r\nORGANIZER:mailto:boss@yandex.ru\r\n.*`)
+
+	var nilInfo *ConferenceInfo
+
+	_, err = nilInfo.ICS(event, ICS_METHOD_REQUEST)
+	c.Assert(err, Equals, ErrNilConferenceInfo)
+	_, err = (&ConferenceInfo{}).ICS(event, ICS_METHOD_REQUEST)
+	c.Assert(err, Equals, ErrEmptyID)
+	_, err = info.ICS(nil, ICS_METHOD_REQUEST)
+	c.Assert(err, Equals, ErrNilEvent)
+	_, err = info.ICS(&Event{}, ICS_METHOD_REQUEST)
+	c.Assert(err, Equals, ErrEmptyEventTime)
+	_, err = info.ICS(&Event{Start: start, End: start.Add(-time.Hour)}, ICS_METHOD_REQUEST)
+	c.Assert(err, ErrorMatches, `Event end time is before start time`)
+	_, err = info.ICS(&Event{Start: start, End: start, Sequence: -1}, ICS_METHOD_REQUEST)
+	c.Assert(err, ErrorMatches, `Event sequence must be equal or greater than 0 \(-1\)`)
+	_, err = info.ICS(&Event{Start: start, End: start}, ICS_METHOD_CANCEL)
+	c.Assert(err, Equals, ErrEmptyOrganizer)
+	_, err = info.ICS(&Event{Start: start, End: start, Organizer: "boss@yandex.ru\r\nATTENDEE:mailto:evil@example.com"}, ICS_METHOD_REQUEST)
+	c.Assert(err, ErrorMatches, `Invalid organizer email .*`)
+	_, err = (&ConferenceInfo{
+		ID:         "12345678901234",
+		Conference: Conference{CoHosts: Hosts{{Email: "user@yandex.ru\nX-EVIL:1"}}},
+	}).ICS(event, ICS_METHOD_REQUEST)
+	c.Assert(err, ErrorMatches, `Invalid cohost email .*`)
+	_, err = info.ICS(event, "PUBLISH")
+	c.Assert(err, ErrorMatches, `Unsupported iCalendar method "PUBLISH"`)
 }
 
 func (s *TelemostSuite) TestErrors(c *C) {
@@ -292,6 +2044,97 @@ func (s *TelemostSuite) TestErrors(c *C) {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+type mapResolver map[string]string
+
+func (r mapResolver) ResolveSIP(ctx context.Context, sip string) (string, error) {
+	id, ok := r[strings.ToLower(sip)]
+
+	if !ok {
+		return "", ErrUnknownSIP
+	}
+
+	return id, nil
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []string
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+}
+
+type traceKey struct{}
+
+func (t *recordingTracer) StartOperation(ctx context.Context, op, id string, cohosts int) (context.Context, Span) {
+	t.record(fmt.Sprintf("start %s %s %d", op, id, cohosts))
+	return ctx, recordingSpan{t}
+}
+
+func (t *recordingTracer) StartRequest(ctx context.Context, method, endpoint string, attempt int) (context.Context, Span) {
+	name := fmt.Sprintf("%s %s %d", method, endpoint, attempt)
+	t.record("start " + name)
+	return context.WithValue(ctx, traceKey{}, name), recordingSpan{t}
+}
+
+func (t *recordingTracer) Inject(ctx context.Context, header http.Header) {
+	header.Set("X-Trace", ctx.Value(traceKey{}).(string))
+}
+
+func (t *recordingTracer) record(span string) {
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+}
+
+func (s recordingSpan) SetConferenceID(id string) {
+	s.tracer.record("id " + id)
+}
+
+func (s recordingSpan) SetCohostsCount(count int) {
+	s.tracer.record(fmt.Sprintf("cohosts %d", count))
+}
+
+func (s recordingSpan) End(statusCode int, err error) {
+	s.tracer.record(fmt.Sprintf("end %d %v", statusCode, err))
+}
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []time.Duration
+	waits    []time.Duration
+}
+
+func (m *recordingMetrics) ObserveRequest(op string, statusCode int, latency time.Duration, err error) {
+	m.mu.Lock()
+	m.requests = append(m.requests, latency)
+	m.mu.Unlock()
+}
+
+func (m *recordingMetrics) ObserveRetry(op string) {}
+
+func (m *recordingMetrics) ObserveRateLimitWait(op string, wait time.Duration) {
+	m.mu.Lock()
+	m.waits = append(m.waits, wait)
+	m.mu.Unlock()
+}
+
+type countingTransport struct {
+	count  atomic.Int32
+	header http.Header
+	query  string
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	t.header = r.Header.Clone()
+	t.query = r.URL.RawQuery
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 func handlerCreateConference(rw http.ResponseWriter, r *http.Request) {
 	if writeErrorResponse(rw, r) {
 		return
@@ -345,6 +2188,33 @@ func handlerUpdateConference(rw http.ResponseWriter, r *http.Request) {
 }`))
 }
 
+func handlerSlowConference(rw http.ResponseWriter, r *http.Request) {
+	select {
+	case <-r.Context().Done():
+	case <-time.After(5 * time.Second):
+	}
+
+	rw.WriteHeader(200)
+	rw.Write([]byte(`{"id": "98765432109876"}`))
+}
+
+func handlerFlaky(rw http.ResponseWriter, r *http.Request) {
+	if flakyCounter.Add(1) <= flakyFailures.Load() {
+		code, _ := strconv.Atoi(strings.TrimPrefix(r.Header.Get("Authorization"), "OAuth flaky-"))
+
+		if code == 429 {
+			rw.Header().Set("Retry-After", "1")
+		}
+
+		rw.WriteHeader(code)
+		rw.Write([]byte(`{"description": "` + http.StatusText(code) + `", "error": "Error` + strconv.Itoa(code) + `"}`))
+		return
+	}
+
+	rw.WriteHeader(200)
+	rw.Write([]byte(`{"id": "12345678901234"}`))
+}
+
 func handlerDeleteConference(rw http.ResponseWriter, r *http.Request) {
 	if writeErrorResponse(rw, r) {
 		return
@@ -358,6 +2228,12 @@ func handlerGetCohosts(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("offset") != "0" {
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"cohosts": []}`))
+		return
+	}
+
 	rw.WriteHeader(200)
 	rw.Write([]byte(`{
   "cohosts": [
@@ -371,6 +2247,44 @@ func handlerGetCohosts(rw http.ResponseWriter, r *http.Request) {
 }`))
 }
 
+func handlerPagedCohosts(total int) http.HandlerFunc {
+	return handlerCappedCohosts(total, math.MaxInt32)
+}
+
+func handlerCappedCohosts(total, maxLimit int) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if writeErrorResponse(rw, r) {
+			return
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		limit = min(limit, maxLimit)
+
+		var cohosts []string
+
+		for i := offset; i < min(offset+limit, total); i++ {
+			cohosts = append(cohosts, `{"email": "user`+strconv.Itoa(i+1)+`@domain.com"}`)
+		}
+
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"cohosts": [` + strings.Join(cohosts, ",") + `]}`))
+	}
+}
+
+func handlerRepeatedCohosts(rw http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	var cohosts []string
+
+	for i := range limit {
+		cohosts = append(cohosts, `{"email": "user`+strconv.Itoa(i+1)+`@domain.com"}`)
+	}
+
+	rw.WriteHeader(200)
+	rw.Write([]byte(`{"cohosts": [` + strings.Join(cohosts, ",") + `]}`))
+}
+
 func handlerAddCohosts(rw http.ResponseWriter, r *http.Request) {
 	if writeErrorResponse(rw, r) {
 		return
@@ -395,6 +2309,11 @@ func handlerDeleteCohosts(rw http.ResponseWriter, r *http.Request) {
 	rw.WriteHeader(204)
 }
 
+func resetFlaky(failures int32) {
+	flakyCounter.Store(0)
+	flakyFailures.Store(failures)
+}
+
 func writeErrorResponse(rw http.ResponseWriter, r *http.Request) bool {
 	if r.Header.Get("Authorization") == "OAuth http-error" {
 		rw.WriteHeader(404)
@@ -406,6 +2325,20 @@ func writeErrorResponse(rw http.ResponseWriter, r *http.Request) bool {
 		return true
 	}
 
+	if strings.HasPrefix(r.Header.Get("Authorization"), "OAuth status-") {
+		code, _ := strconv.Atoi(strings.TrimPrefix(r.Header.Get("Authorization"), "OAuth status-"))
+		rw.Header().Set("X-Request-Id", "abcd1234")
+		rw.WriteHeader(code)
+		rw.Write([]byte(`{"description": "` + http.StatusText(code) + `", "error": "Error` + strconv.Itoa(code) + `"}`))
+		return true
+	}
+
+	if r.Header.Get("Authorization") == "OAuth expired-token" {
+		rw.WriteHeader(401)
+		rw.Write([]byte(`{"description": "Unauthorized", "error": "UnauthorizedError"}`))
+		return true
+	}
+
 	if r.Header.Get("Authorization") == "OAuth msg-error" {
 		rw.WriteHeader(404)
 		rw.Write([]byte(`XXX`))
@@ -0,0 +1,137 @@
+// Package telemostotel provides OpenTelemetry tracer for Telemost client
+package telemostotel
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/essentialkaos/telemost"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TRACER_NAME is name of tracer used for instrumentation
+const TRACER_NAME = "github.com/essentialkaos/telemost"
+
+// Span attributes
+const (
+	ATTR_CONFERENCE_ID = "telemost.conference.id"
+	ATTR_COHOSTS_COUNT = "telemost.cohosts.count"
+	ATTR_ATTEMPT       = "telemost.attempt"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Tracer is OpenTelemetry tracer of client operations and API requests. It
+// passes W3C trace context to API.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// span is wrapper for OpenTelemetry span
+type span struct {
+	span trace.Span
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ telemost.Tracer = (*Tracer)(nil)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewTracer creates new tracer using given provider or global provider if it
+// is nil
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	return &Tracer{
+		tracer:     tp.Tracer(TRACER_NAME),
+		propagator: propagation.TraceContext{},
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// StartOperation starts span for high-level client operation
+func (t *Tracer) StartOperation(ctx context.Context, op, id string, cohosts int) (context.Context, telemost.Span) {
+	var attrs []attribute.KeyValue
+
+	if id != "" {
+		attrs = append(attrs, attribute.String(ATTR_CONFERENCE_ID, id))
+	}
+
+	if cohosts > 0 {
+		attrs = append(attrs, attribute.Int(ATTR_COHOSTS_COUNT, cohosts))
+	}
+
+	ctx, s := t.tracer.Start(
+		ctx, "telemost."+op,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, &span{s}
+}
+
+// StartRequest starts span for single HTTP request to API
+func (t *Tracer) StartRequest(ctx context.Context, method, endpoint string, attempt int) (context.Context, telemost.Span) {
+	ctx, s := t.tracer.Start(
+		ctx, "HTTP "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.path", endpoint),
+			attribute.Int(ATTR_ATTEMPT, attempt),
+			attribute.Int("http.request.resend_count", attempt-1),
+		),
+	)
+
+	return ctx, &span{s}
+}
+
+// Inject adds W3C trace context headers to request
+func (t *Tracer) Inject(ctx context.Context, header http.Header) {
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetConferenceID sets conference ID attribute
+func (s *span) SetConferenceID(id string) {
+	s.span.SetAttributes(attribute.String(ATTR_CONFERENCE_ID, id))
+}
+
+// SetCohostsCount sets cohosts count attribute
+func (s *span) SetCohostsCount(count int) {
+	s.span.SetAttributes(attribute.Int(ATTR_COHOSTS_COUNT, count))
+}
+
+// End records result and ends span
+func (s *span) End(statusCode int, err error) {
+	if statusCode > 0 {
+		s.span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	}
+
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+
+	s.span.End()
+}
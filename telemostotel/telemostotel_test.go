@@ -0,0 +1,149 @@
+package telemostotel
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/telemosttest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TelemostOtelSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&TelemostOtelSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelemostOtelSuite) TestTracer(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	rt := &headerTransport{}
+
+	api, err := telemost.NewClient(
+		"Test1234",
+		telemost.WithBaseURL(srv.URL),
+		telemost.WithTracer(NewTracer(tp)),
+		telemost.WithTransport(rt),
+	)
+
+	c.Assert(err, IsNil)
+
+	info, err := api.Create(&telemost.Conference{})
+	c.Assert(err, IsNil)
+
+	spans := exporter.GetSpans()
+
+	c.Assert(spans, HasLen, 2)
+	c.Assert(spans[1].Name, Equals, "telemost.Create")
+	c.Assert(spans[1].Parent.IsValid(), Equals, false)
+	c.Assert(spans[1].Attributes, DeepEquals, []attribute.KeyValue{
+		attribute.String(ATTR_CONFERENCE_ID, info.ID),
+	})
+
+	exporter.Reset()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	err = api.AddCohostsContext(ctx, info.ID, []string{"user1@yandex.ru", "user2@yandex.ru"})
+	c.Assert(err, IsNil)
+
+	parent.End()
+
+	spans = exporter.GetSpans()
+
+	c.Assert(spans, HasLen, 3)
+
+	attempt, op := spans[0], spans[1]
+
+	c.Assert(op.Name, Equals, "telemost.AddCohosts")
+	c.Assert(op.Parent.SpanID(), Equals, parent.SpanContext().SpanID())
+	c.Assert(op.Attributes, DeepEquals, []attribute.KeyValue{
+		attribute.String(ATTR_CONFERENCE_ID, info.ID),
+		attribute.Int(ATTR_COHOSTS_COUNT, 2),
+	})
+
+	c.Assert(attempt.Name, Equals, "HTTP PATCH")
+	c.Assert(attempt.SpanKind, Equals, trace.SpanKindClient)
+	c.Assert(attempt.Parent.SpanID(), Equals, op.SpanContext.SpanID())
+	c.Assert(attempt.SpanContext.TraceID(), Equals, parent.SpanContext().TraceID())
+	c.Assert(attempt.Attributes, DeepEquals, []attribute.KeyValue{
+		attribute.String("http.request.method", "PATCH"),
+		attribute.String("url.path", "/"+info.ID+"/cohosts"),
+		attribute.Int(ATTR_ATTEMPT, 1),
+		attribute.Int("http.request.resend_count", 0),
+		attribute.Int("http.response.status_code", 204),
+	})
+
+	c.Assert(rt.header.Get("Traceparent"), Equals, fmt.Sprintf(
+		"00-%s-%s-01", attempt.SpanContext.TraceID(), attempt.SpanContext.SpanID(),
+	))
+
+	exporter.Reset()
+
+	_, err = api.GetCohosts(info.ID)
+	c.Assert(err, IsNil)
+
+	spans = exporter.GetSpans()
+
+	c.Assert(spans, HasLen, 3)
+	c.Assert(spans[2].Name, Equals, "telemost.GetCohosts")
+	c.Assert(spans[2].Status.Code, Equals, codes.Unset)
+	c.Assert(spans[2].Attributes, DeepEquals, []attribute.KeyValue{
+		attribute.String(ATTR_CONFERENCE_ID, info.ID),
+		attribute.Int(ATTR_COHOSTS_COUNT, 2),
+	})
+
+	exporter.Reset()
+
+	_, err = api.Get("12345678901234")
+	c.Assert(err, NotNil)
+
+	spans = exporter.GetSpans()
+
+	c.Assert(spans, HasLen, 2)
+	c.Assert(spans[0].Status.Code, Equals, codes.Error)
+	c.Assert(spans[0].Events[0].Name, Equals, "exception")
+	c.Assert(spans[1].Name, Equals, "telemost.Get")
+	c.Assert(spans[1].Status.Code, Equals, codes.Error)
+}
+
+func (s *TelemostOtelSuite) TestGlobalProvider(c *C) {
+	c.Assert(NewTracer(nil).tracer, NotNil)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type headerTransport struct {
+	header http.Header
+}
+
+func (t *headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.header = r.Header.Clone()
+	return http.DefaultTransport.RoundTrip(r)
+}
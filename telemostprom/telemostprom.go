@@ -0,0 +1,120 @@
+// Package telemostprom provides Prometheus collector for Telemost client metrics
+package telemostprom
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/essentialkaos/telemost"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_NAMESPACE is default namespace of metrics
+const DEFAULT_NAMESPACE = "telemost"
+
+// STATUS_ERROR is status label value for requests without response
+const STATUS_ERROR = "error"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Collector is Prometheus collector for client metrics
+type Collector struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	waits    *prometheus.HistogramVec
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	_ prometheus.Collector = (*Collector)(nil)
+	_ telemost.Metrics     = (*Collector)(nil)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewCollector creates new collector with given metrics namespace
+func NewCollector(namespace string) *Collector {
+	if namespace == "" {
+		namespace = DEFAULT_NAMESPACE
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Total number of requests to Telemost API.",
+		}, []string{"operation", "status"}),
+
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests to Telemost API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Total number of retried requests to Telemost API.",
+		}, []string{"operation"}),
+
+		waits: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_wait_seconds",
+			Help:      "Time spent waiting for client-side rate limiter.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Describe implements prometheus.Collector interface
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.latency.Describe(ch)
+	c.retries.Describe(ch)
+	c.waits.Describe(ch)
+}
+
+// Collect implements prometheus.Collector interface
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.latency.Collect(ch)
+	c.retries.Collect(ch)
+	c.waits.Collect(ch)
+}
+
+// ObserveRequest implements telemost.Metrics interface
+func (c *Collector) ObserveRequest(op string, statusCode int, latency time.Duration, err error) {
+	status := STATUS_ERROR
+
+	if statusCode > 0 {
+		status = strconv.Itoa(statusCode)
+	}
+
+	c.requests.WithLabelValues(op, status).Inc()
+	c.latency.WithLabelValues(op).Observe(latency.Seconds())
+}
+
+// ObserveRetry implements telemost.Metrics interface
+func (c *Collector) ObserveRetry(op string) {
+	c.retries.WithLabelValues(op).Inc()
+}
+
+// ObserveRateLimitWait implements telemost.Metrics interface
+func (c *Collector) ObserveRateLimitWait(op string, wait time.Duration) {
+	c.waits.WithLabelValues(op).Observe(wait.Seconds())
+}
@@ -0,0 +1,107 @@
+package telemostprom
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/essentialkaos/telemost"
+	"github.com/essentialkaos/telemost/telemosttest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TelemostPromSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&TelemostPromSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelemostPromSuite) TestCollector(c *C) {
+	srv := telemosttest.NewServer()
+	defer srv.Close()
+
+	collector := NewCollector("")
+	registry := prometheus.NewPedanticRegistry()
+
+	c.Assert(registry.Register(collector), IsNil)
+
+	api, err := telemost.NewClient(
+		"Test1234",
+		telemost.WithBaseURL(srv.URL),
+		telemost.WithMetrics(collector),
+		telemost.WithLimiter(telemost.NewLimiter(1000, 10)),
+		telemost.WithRetryPolicy(telemost.RetryPolicy{
+			MaxAttempts: 3,
+			MinDelay:    time.Millisecond,
+			MaxDelay:    time.Millisecond,
+		}),
+	)
+
+	c.Assert(err, IsNil)
+
+	info, err := api.Create(&telemost.Conference{})
+	c.Assert(err, IsNil)
+
+	srv.FailNext(1, 503)
+
+	_, err = api.Get(info.ID)
+	c.Assert(err, IsNil)
+
+	_, err = api.Get("1")
+	c.Assert(err, NotNil)
+
+	c.Assert(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP telemost_requests_total Total number of requests to Telemost API.
+# TYPE telemost_requests_total counter
+telemost_requests_total{operation="Create",status="201"} 1
+telemost_requests_total{operation="Get",status="200"} 1
+telemost_requests_total{operation="Get",status="404"} 1
+telemost_requests_total{operation="Get",status="503"} 1
+# HELP telemost_retries_total Total number of retried requests to Telemost API.
+# TYPE telemost_retries_total counter
+telemost_retries_total{operation="Get"} 1
+`), "telemost_requests_total", "telemost_retries_total"), IsNil)
+
+	c.Assert(testutil.CollectAndCount(collector, "telemost_request_duration_seconds"), Equals, 2)
+	c.Assert(testutil.CollectAndCount(collector, "telemost_rate_limit_wait_seconds"), Equals, 2)
+
+	count, err := testutil.GatherAndCount(registry)
+
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 9)
+
+	_, err = telemost.NewClient("Test1234", telemost.WithMetrics(nil))
+	c.Assert(err, Equals, telemost.ErrNilMetrics)
+}
+
+func (s *TelemostPromSuite) TestNetworkErrors(c *C) {
+	collector := NewCollector("test")
+
+	api, _ := telemost.NewClient(
+		"Test1234",
+		telemost.WithBaseURL("http://127.0.0.1:1"),
+		telemost.WithMetrics(collector),
+	)
+
+	_, err := api.Get("1")
+	c.Assert(err, NotNil)
+
+	c.Assert(testutil.ToFloat64(collector.requests.WithLabelValues("Get", STATUS_ERROR)), Equals, 1.0)
+}
@@ -0,0 +1,632 @@
+// Package telemosttest provides in-memory fake Yandex.Telemost API server for tests
+package telemosttest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MAX_COHOSTS is maximum number of conference cohosts accepted by server
+const MAX_COHOSTS = 30
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Server is in-memory fake Yandex.Telemost API server
+type Server struct {
+	// URL is base URL of server API which should be passed to telemost.WithBaseURL
+	URL string
+
+	srv *httptest.Server
+	mu  sync.Mutex
+
+	tokens      map[string]bool
+	conferences map[string]*Conference
+	order       []string
+	counter     int64
+	requests    int
+
+	latency   time.Duration
+	failures  []int
+	malformed int
+}
+
+// Conference contains conference state stored by server
+type Conference struct {
+	ID               string      `json:"id"`
+	JoinURL          string      `json:"join_url"`
+	SIPURIMeeting    string      `json:"sip_uri_meeting"`
+	SIPURITelemost   string      `json:"sip_uri_telemost"`
+	SIPID            string      `json:"sip_id"`
+	WaitingRoomLevel string      `json:"waiting_room_level,omitempty"`
+	LiveStream       *LiveStream `json:"live_stream,omitempty"`
+	Cohosts          []string    `json:"-"`
+}
+
+// LiveStream contains info about conference stream stored by server
+type LiveStream struct {
+	WatchURL    string `json:"watch_url,omitempty"`
+	AccessLevel string `json:"access_level,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// host contains info about cohost
+type host struct {
+	Email string `json:"email"`
+}
+
+// cohostsPayload contains cohosts list
+type cohostsPayload struct {
+	Cohosts []host `json:"cohosts"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewServer starts new fake server which accepts given tokens. If no tokens are
+// given, server accepts any non-empty token.
+func NewServer(tokens ...string) *Server {
+	s := &Server{
+		tokens:      make(map[string]bool),
+		conferences: make(map[string]*Conference),
+		counter:     10000000000000,
+	}
+
+	for _, token := range tokens {
+		s.tokens[token] = true
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /{$}", s.handleCreate)
+	mux.HandleFunc("GET /{id}", s.handleGet)
+	mux.HandleFunc("PATCH /{id}", s.handleUpdate)
+	mux.HandleFunc("DELETE /{id}", s.handleDelete)
+	mux.HandleFunc("GET /{id}/cohosts", s.handleGetCohosts)
+	mux.HandleFunc("PATCH /{id}/cohosts", s.handleAddCohosts)
+	mux.HandleFunc("PUT /{id}/cohosts", s.handleUpdateCohosts)
+	mux.HandleFunc("DELETE /{id}/cohosts", s.handleDeleteCohosts)
+
+	s.srv = httptest.NewServer(s.middleware(mux))
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Close shuts down server
+func (s *Server) Close() {
+	if s == nil || s.srv == nil {
+		return
+	}
+
+	s.srv.Close()
+}
+
+// AddToken adds token to the list of accepted tokens
+func (s *Server) AddToken(token string) {
+	s.mu.Lock()
+	s.tokens[token] = true
+	s.mu.Unlock()
+}
+
+// SetLatency sets delay before every response
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	s.latency = latency
+	s.mu.Unlock()
+}
+
+// FailNext makes server respond with given status code to next n requests
+func (s *Server) FailNext(n, statusCode int) {
+	s.mu.Lock()
+
+	for range n {
+		s.failures = append(s.failures, statusCode)
+	}
+
+	s.mu.Unlock()
+}
+
+// MalformNext makes server respond with malformed JSON to next n requests
+func (s *Server) MalformNext(n int) {
+	s.mu.Lock()
+	s.malformed += n
+	s.mu.Unlock()
+}
+
+// Requests returns total number of requests received by server
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// Conference returns copy of stored conference with given ID
+func (s *Server) Conference(id string) (*Conference, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conf, ok := s.conferences[id]
+
+	if !ok {
+		return nil, false
+	}
+
+	return conf.clone(), true
+}
+
+// Conferences returns copies of all stored conferences in creation order
+func (s *Server) Conferences() []*Conference {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*Conference
+
+	for _, id := range s.order {
+		result = append(result, s.conferences[id].clone())
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// middleware checks authorization and injects faults
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+
+		s.requests++
+		latency := s.latency
+		failure, malformed := 0, false
+
+		if len(s.failures) != 0 {
+			failure, s.failures = s.failures[0], s.failures[1:]
+		} else if s.malformed > 0 {
+			s.malformed--
+			malformed = true
+		}
+
+		authorized := s.isAuthorized(r.Header.Get("Authorization"))
+
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(latency):
+			}
+		}
+
+		switch {
+		case failure != 0:
+			writeError(rw, failure, "InjectedError", http.StatusText(failure))
+		case malformed:
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`{"id": `))
+		case !authorized:
+			writeError(rw, http.StatusUnauthorized, "UnauthorizedError", "Unauthorized")
+		default:
+			next.ServeHTTP(rw, r)
+		}
+	})
+}
+
+// isAuthorized checks authorization header
+func (s *Server) isAuthorized(header string) bool {
+	token, ok := strings.CutPrefix(header, "OAuth ")
+
+	if !ok || token == "" {
+		return false
+	}
+
+	return len(s.tokens) == 0 || s.tokens[token]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// handleCreate handles conference creation
+func (s *Server) handleCreate(rw http.ResponseWriter, r *http.Request) {
+	fields, ok := readFields(rw, r)
+
+	if !ok {
+		return
+	}
+
+	conf := &Conference{}
+
+	if !applyFields(rw, conf, fields) {
+		return
+	}
+
+	s.mu.Lock()
+
+	s.counter++
+	id := strconv.FormatInt(s.counter, 10)
+
+	conf.ID = id
+	conf.JoinURL = "https://telemost.yandex.ru/j/" + id
+	conf.SIPID = id + "000000"
+	conf.SIPURIMeeting = conf.SIPID + "@sip.t.ya.ru"
+	conf.SIPURITelemost = "j@sip.t.ya.ru"
+
+	if conf.LiveStream != nil {
+		hash := md5.Sum([]byte(id))
+		conf.LiveStream.WatchURL = "https://telemost.yandex.ru/live/" + hex.EncodeToString(hash[:])
+	}
+
+	s.conferences[id] = conf
+	s.order = append(s.order, id)
+	resp := conf.clone()
+
+	s.mu.Unlock()
+
+	writeJSON(rw, http.StatusCreated, resp.view())
+}
+
+// handleGet handles conference info request
+func (s *Server) handleGet(rw http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	conf, ok := s.conferences[r.PathValue("id")]
+
+	if ok {
+		conf = conf.clone()
+	}
+
+	s.mu.Unlock()
+
+	if !ok {
+		writeNotFound(rw)
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, conf.view())
+}
+
+// handleUpdate handles conference update
+func (s *Server) handleUpdate(rw http.ResponseWriter, r *http.Request) {
+	fields, ok := readFields(rw, r)
+
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conf, ok := s.conferences[r.PathValue("id")]
+
+	if !ok {
+		writeNotFound(rw)
+		return
+	}
+
+	updated := conf.clone()
+
+	if !applyFields(rw, updated, fields) {
+		return
+	}
+
+	s.conferences[conf.ID] = updated
+
+	writeJSON(rw, http.StatusOK, updated.view())
+}
+
+// handleDelete handles conference deletion
+func (s *Server) handleDelete(rw http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+
+	if s.conferences[id] == nil {
+		writeNotFound(rw)
+		return
+	}
+
+	delete(s.conferences, id)
+	s.order = slices.DeleteFunc(s.order, func(v string) bool { return v == id })
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetCohosts handles cohosts list request
+func (s *Server) handleGetCohosts(rw http.ResponseWriter, r *http.Request) {
+	offset, err1 := parseIntParam(r, "offset", 0)
+	limit, err2 := parseIntParam(r, "limit", 20)
+
+	if err1 != nil || err2 != nil || offset < 0 || limit < 1 {
+		writeError(rw, http.StatusBadRequest, "ValidationError", "Invalid pagination parameters")
+		return
+	}
+
+	s.mu.Lock()
+	conf, ok := s.conferences[r.PathValue("id")]
+
+	var cohosts []string
+
+	if ok {
+		cohosts = slices.Clone(conf.Cohosts)
+	}
+
+	s.mu.Unlock()
+
+	if !ok {
+		writeNotFound(rw)
+		return
+	}
+
+	resp := cohostsPayload{Cohosts: []host{}}
+
+	for i := offset; i < min(offset+limit, len(cohosts)); i++ {
+		resp.Cohosts = append(resp.Cohosts, host{cohosts[i]})
+	}
+
+	writeJSON(rw, http.StatusOK, resp)
+}
+
+// handleAddCohosts handles cohosts addition
+func (s *Server) handleAddCohosts(rw http.ResponseWriter, r *http.Request) {
+	s.modifyCohosts(rw, r, func(current, emails []string) []string {
+		for _, email := range emails {
+			if !slices.Contains(current, email) {
+				current = append(current, email)
+			}
+		}
+
+		return current
+	})
+}
+
+// handleUpdateCohosts handles cohosts replacement
+func (s *Server) handleUpdateCohosts(rw http.ResponseWriter, r *http.Request) {
+	s.modifyCohosts(rw, r, func(_, emails []string) []string {
+		return emails
+	})
+}
+
+// handleDeleteCohosts handles cohosts removal
+func (s *Server) handleDeleteCohosts(rw http.ResponseWriter, r *http.Request) {
+	emails := strings.Split(r.URL.Query().Get("cohost_emails"), ",")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conf, ok := s.conferences[r.PathValue("id")]
+
+	if !ok {
+		writeNotFound(rw)
+		return
+	}
+
+	conf.Cohosts = slices.DeleteFunc(conf.Cohosts, func(email string) bool {
+		return slices.Contains(emails, email)
+	})
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// modifyCohosts modifies cohosts of conference using given function
+func (s *Server) modifyCohosts(rw http.ResponseWriter, r *http.Request, fn func(current, emails []string) []string) {
+	payload := &cohostsPayload{}
+	err := json.NewDecoder(r.Body).Decode(payload)
+
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "ValidationError", "Can't decode request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conf, ok := s.conferences[r.PathValue("id")]
+
+	if !ok {
+		writeNotFound(rw)
+		return
+	}
+
+	cohosts := fn(slices.Clone(conf.Cohosts), flattenHosts(payload.Cohosts))
+
+	if len(cohosts) > MAX_COHOSTS {
+		writeError(rw, http.StatusBadRequest, "ValidationError", "Too many cohosts")
+		return
+	}
+
+	conf.Cohosts = cohosts
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// view returns conference representation used in API responses
+func (c *Conference) view() any {
+	v := &struct {
+		*Conference
+		Cohosts []host `json:"cohosts,omitempty"`
+	}{Conference: c}
+
+	for _, email := range c.Cohosts {
+		v.Cohosts = append(v.Cohosts, host{email})
+	}
+
+	return v
+}
+
+// clone creates deep copy of conference
+func (c *Conference) clone() *Conference {
+	cc := *c
+	cc.Cohosts = slices.Clone(c.Cohosts)
+
+	if c.LiveStream != nil {
+		ls := *c.LiveStream
+		cc.LiveStream = &ls
+	}
+
+	return &cc
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readFields reads request body as a map with raw fields
+func readFields(rw http.ResponseWriter, r *http.Request) (map[string]json.RawMessage, bool) {
+	fields := map[string]json.RawMessage{}
+	err := json.NewDecoder(r.Body).Decode(&fields)
+
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "ValidationError", "Can't decode request body")
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// applyFields applies given fields to conference. Fields with null value are
+// cleared.
+func applyFields(rw http.ResponseWriter, conf *Conference, fields map[string]json.RawMessage) bool {
+	var err error
+
+	for name, value := range fields {
+		isNull := string(value) == "null"
+
+		switch name {
+		case "waiting_room_level":
+			conf.WaitingRoomLevel = ""
+
+			if !isNull {
+				err = json.Unmarshal(value, &conf.WaitingRoomLevel)
+			}
+
+		case "live_stream":
+			if isNull {
+				conf.LiveStream = nil
+				continue
+			}
+
+			if conf.LiveStream == nil {
+				conf.LiveStream = &LiveStream{}
+			}
+
+			err = applyLiveStreamFields(conf.LiveStream, value)
+
+		case "cohosts":
+			var hosts []host
+
+			if !isNull {
+				err = json.Unmarshal(value, &hosts)
+			}
+
+			conf.Cohosts = flattenHosts(hosts)
+
+			if len(conf.Cohosts) > MAX_COHOSTS {
+				err = fmt.Errorf("Too many cohosts")
+			}
+		}
+
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "ValidationError", err.Error())
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyLiveStreamFields applies given fields to live stream
+func applyLiveStreamFields(ls *LiveStream, data json.RawMessage) error {
+	fields := map[string]*string{}
+	err := json.Unmarshal(data, &fields)
+
+	if err != nil {
+		return err
+	}
+
+	for name, value := range fields {
+		v := ""
+
+		if value != nil {
+			v = *value
+		}
+
+		switch name {
+		case "access_level":
+			ls.AccessLevel = v
+		case "title":
+			ls.Title = v
+		case "description":
+			ls.Description = v
+		}
+	}
+
+	return nil
+}
+
+// flattenHosts converts slice of hosts to slice with emails
+func flattenHosts(hosts []host) []string {
+	var result []string
+
+	for _, h := range hosts {
+		if !slices.Contains(result, h.Email) {
+			result = append(result, h.Email)
+		}
+	}
+
+	return result
+}
+
+// parseIntParam parses integer query parameter
+func parseIntParam(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+
+	if value == "" {
+		return def, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// writeJSON writes JSON response
+func writeJSON(rw http.ResponseWriter, statusCode int, v any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+	json.NewEncoder(rw).Encode(v)
+}
+
+// writeNotFound writes conference not found error
+func writeNotFound(rw http.ResponseWriter) {
+	writeError(rw, http.StatusNotFound, "ConferenceNotFound", "Conference not found.")
+}
+
+// writeError writes error response
+func writeError(rw http.ResponseWriter, statusCode int, code, description string) {
+	writeJSON(rw, statusCode, map[string]string{
+		"error":       code,
+		"description": description,
+		"message":     description,
+	})
+}
@@ -0,0 +1,213 @@
+package telemosttest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/telemost"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TelemostTestSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&TelemostTestSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelemostTestSuite) TestConferences(c *C) {
+	srv := NewServer("Test1234")
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+
+	info, err := api.Create(&telemost.Conference{
+		WaitingRoomLevel: telemost.ROOM_LEVEL_ORG,
+		LiveStream: &telemost.LiveStream{
+			AccessLevel: telemost.ACCESS_LEVEL_PUBLIC,
+			Title:       "Test",
+		},
+		CoHosts: telemost.Hosts{{Email: "user1@yandex.ru"}},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(info.ID, Equals, "10000000000001")
+	c.Assert(info.JoinURL, Equals, "https://telemost.yandex.ru/j/10000000000001")
+	c.Assert(info.SIPID, Equals, "10000000000001000000")
+	c.Assert(info.SIPURIMeeting, Equals, "10000000000001000000@sip.t.ya.ru")
+	c.Assert(info.LiveStream.WatchURL, Matches, `https://telemost.yandex.ru/live/[0-9a-f]{32}`)
+	c.Assert(info.CoHosts.Flatten(), DeepEquals, []string{"user1@yandex.ru"})
+
+	info, err = api.Update(info.ID, &telemost.Conference{
+		LiveStream: &telemost.LiveStream{
+			AccessLevel: telemost.ACCESS_LEVEL_PUBLIC,
+			Title:       "Test",
+			Description: "Description",
+		},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(info.WaitingRoomLevel, Equals, telemost.ROOM_LEVEL_ORG)
+	c.Assert(info.LiveStream.Title, Equals, "Test")
+	c.Assert(info.LiveStream.Description, Equals, "Description")
+
+	info, err = api.Get(info.ID)
+
+	c.Assert(err, IsNil)
+	c.Assert(info.LiveStream.Description, Equals, "Description")
+
+	info, err = api.Patch(info.ID, telemost.NewPatch().ClearWaitingRoomLevel().ClearDescription())
+
+	c.Assert(err, IsNil)
+	c.Assert(info.WaitingRoomLevel.IsZero(), Equals, true)
+	c.Assert(info.LiveStream.Title, Equals, "Test")
+	c.Assert(info.LiveStream.Description, Equals, "")
+
+	info, err = api.Patch(info.ID, telemost.NewPatch().SetWaitingRoomLevel(telemost.ROOM_LEVEL_ORG))
+
+	c.Assert(err, IsNil)
+	c.Assert(info.WaitingRoomLevel, Equals, telemost.ROOM_LEVEL_ORG)
+
+	conf, ok := srv.Conference(info.ID)
+
+	c.Assert(ok, Equals, true)
+	c.Assert(conf.Cohosts, DeepEquals, []string{"user1@yandex.ru"})
+	c.Assert(srv.Conferences(), HasLen, 1)
+
+	c.Assert(api.Delete(info.ID), IsNil)
+
+	_, err = api.Get(info.ID)
+	c.Assert(errors.Is(err, telemost.ErrNotFound), Equals, true)
+	_, err = api.Update(info.ID, &telemost.Conference{})
+	c.Assert(errors.Is(err, telemost.ErrNotFound), Equals, true)
+	err = api.Delete(info.ID)
+	c.Assert(errors.Is(err, telemost.ErrNotFound), Equals, true)
+
+	_, ok = srv.Conference(info.ID)
+	c.Assert(ok, Equals, false)
+	c.Assert(srv.Conferences(), HasLen, 0)
+	c.Assert(srv.Requests(), Equals, 9)
+}
+
+func (s *TelemostTestSuite) TestCohosts(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL), telemost.WithPageSize(3))
+	info, _ := api.Create(&telemost.Conference{})
+
+	var emails []string
+
+	for i := range 7 {
+		emails = append(emails, "user"+strconv.Itoa(i)+"@yandex.ru")
+	}
+
+	c.Assert(api.AddCohosts(info.ID, emails), IsNil)
+	c.Assert(api.AddCohosts(info.ID, emails[:2]), IsNil)
+
+	cohosts, err := api.GetCohosts(info.ID)
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts.Flatten(), DeepEquals, emails)
+
+	c.Assert(api.DeleteCohosts(info.ID, emails[1:6]), IsNil)
+
+	cohosts, err = api.GetCohosts(info.ID)
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts.Flatten(), DeepEquals, []string{"user0@yandex.ru", "user6@yandex.ru"})
+
+	c.Assert(api.UpdateCohosts(info.ID, []string{"admin@yandex.ru"}), IsNil)
+
+	cohosts, err = api.GetCohosts(info.ID)
+
+	c.Assert(err, IsNil)
+	c.Assert(cohosts.Flatten(), DeepEquals, []string{"admin@yandex.ru"})
+
+	var many []string
+
+	for i := range 40 {
+		many = append(many, "user"+strconv.Itoa(i)+"@yandex.ru")
+	}
+
+	err = api.AddCohosts(info.ID, many)
+	c.Assert(err, ErrorMatches, `API returned error: Too many cohosts \(ValidationError\)`)
+
+	_, err = api.GetCohosts("1")
+	c.Assert(errors.Is(err, telemost.ErrNotFound), Equals, true)
+	err = api.AddCohosts("1", emails)
+	c.Assert(errors.Is(err, telemost.ErrNotFound), Equals, true)
+	err = api.DeleteCohosts("1", emails)
+	c.Assert(errors.Is(err, telemost.ErrNotFound), Equals, true)
+}
+
+func (s *TelemostTestSuite) TestAuth(c *C) {
+	srv := NewServer("Test1234")
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Unknown", telemost.WithBaseURL(srv.URL))
+
+	_, err := api.Create(&telemost.Conference{})
+	c.Assert(errors.Is(err, telemost.ErrUnauthorized), Equals, true)
+
+	srv.AddToken("Unknown")
+
+	_, err = api.Create(&telemost.Conference{})
+	c.Assert(err, IsNil)
+}
+
+func (s *TelemostTestSuite) TestFaults(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, _ := telemost.NewClient("Test1234", telemost.WithBaseURL(srv.URL))
+	info, _ := api.Create(&telemost.Conference{})
+
+	srv.FailNext(2, 503)
+
+	_, err := api.Get(info.ID)
+	c.Assert(err, ErrorMatches, `API returned error: Service Unavailable \(InjectedError\)`)
+	_, err = api.Get(info.ID)
+	c.Assert(err, NotNil)
+	_, err = api.Get(info.ID)
+	c.Assert(err, IsNil)
+
+	srv.MalformNext(1)
+
+	_, err = api.Get(info.ID)
+	c.Assert(err, ErrorMatches, `Can't decode API response: .*`)
+	_, err = api.Get(info.ID)
+	c.Assert(err, IsNil)
+
+	srv.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = api.GetContext(ctx, info.ID)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	srv.SetLatency(0)
+
+	_, err = api.Get(info.ID)
+	c.Assert(err, IsNil)
+
+	var nilSrv *Server
+	nilSrv.Close()
+}
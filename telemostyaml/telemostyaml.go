@@ -0,0 +1,60 @@
+// Package telemostyaml provides loading of conference templates from YAML
+package telemostyaml
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/essentialkaos/telemost"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LoadTemplates loads templates from YAML or JSON file. File format is defined by
+// file extension (.json, .yaml or .yml).
+func LoadTemplates(path string) (telemost.Templates, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read templates: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return telemost.ParseTemplates(data)
+	case ".yaml", ".yml":
+		return ParseTemplates(data)
+	}
+
+	return nil, fmt.Errorf("Unsupported templates format %q", filepath.Ext(path))
+}
+
+// ParseTemplates parses templates from YAML mapping where keys are template names
+func ParseTemplates(data []byte) (telemost.Templates, error) {
+	var templates telemost.Templates
+
+	err := yaml.Unmarshal(data, &templates)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode templates: %w", err)
+	}
+
+	err = templates.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
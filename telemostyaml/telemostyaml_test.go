@@ -0,0 +1,88 @@
+package telemostyaml
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"testing"
+
+	"github.com/essentialkaos/telemost"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TelemostYAMLSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&TelemostYAMLSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelemostYAMLSuite) TestTemplates(c *C) {
+	dir := c.MkDir()
+
+	c.Assert(os.WriteFile(dir+"/templates.yml", []byte(`
+support:
+  waiting_room_level: PUBLIC
+  access_level: ORGANIZATION
+  title: "Support session #{{.ticket}}"
+  description: "Room {{printf \"%03d\" .room}}"
+  cohosts: [support@yandex.ru]
+  vars:
+    team: L1
+    room: 7
+standup:
+  title: Daily standup
+`), 0644), IsNil)
+
+	tmpls, err := LoadTemplates(dir + "/templates.yml")
+	c.Assert(err, IsNil)
+	c.Assert(tmpls.Names(), DeepEquals, []string{"standup", "support"})
+
+	tmpl, _ := tmpls.Get("support")
+	c.Assert(tmpl.Name, Equals, "support")
+	c.Assert(tmpl.WaitingRoomLevel, Equals, telemost.ROOM_LEVEL_PUBLIC)
+	c.Assert(tmpl.AccessLevel, Equals, telemost.ACCESS_LEVEL_ORG)
+	c.Assert(tmpl.Cohosts, DeepEquals, []string{"support@yandex.ru"})
+	c.Assert(tmpl.Vars["team"], Equals, "L1")
+	c.Assert(tmpl.Vars["room"], Equals, 7)
+
+	conf, err := tmpl.Render(map[string]any{"ticket": 42}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(conf.LiveStream.Title, Equals, "Support session #42")
+	c.Assert(conf.LiveStream.Description, Equals, "Room 007")
+
+	c.Assert(os.WriteFile(dir+"/templates.json", []byte(`{"test": {"title": "{{.name}}"}}`), 0644), IsNil)
+
+	tmpls, err = LoadTemplates(dir + "/templates.json")
+	c.Assert(err, IsNil)
+	c.Assert(tmpls, HasLen, 1)
+
+	_, err = ParseTemplates([]byte(`test: {waiting_room_level: ALL}`))
+	c.Assert(err, ErrorMatches, `Template "test" has unknown waiting room level "ALL"`)
+	_, err = ParseTemplates([]byte(`test:`))
+	c.Assert(err, ErrorMatches, `Template "test" is empty`)
+	_, err = ParseTemplates([]byte(`[]`))
+	c.Assert(err, ErrorMatches, "(?s)Can't decode templates: .*")
+
+	_, err = LoadTemplates(dir + "/unknown.yml")
+	c.Assert(err, ErrorMatches, "Can't read templates: .*")
+
+	c.Assert(os.WriteFile(dir+"/templates.txt", nil, 0644), IsNil)
+	c.Assert(os.WriteFile(dir+"/broken.yml", []byte("a: [b"), 0644), IsNil)
+
+	_, err = LoadTemplates(dir + "/templates.txt")
+	c.Assert(err, ErrorMatches, `Unsupported templates format ".txt"`)
+	_, err = LoadTemplates(dir + "/broken.yml")
+	c.Assert(err, ErrorMatches, "(?s)Can't decode templates: .*")
+}
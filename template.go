@@ -0,0 +1,286 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Template is named conference template. Live stream title and description are
+// text/template patterns rendered with template variables.
+type Template struct {
+	Name             string           `json:"-" yaml:"-"`
+	WaitingRoomLevel WaitingRoomLevel `json:"waiting_room_level,omitzero" yaml:"waiting_room_level,omitempty"`
+	AccessLevel      AccessLevel      `json:"access_level,omitzero" yaml:"access_level,omitempty"`
+	Title            string           `json:"title,omitempty" yaml:"title,omitempty"`
+	Description      string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Cohosts          []string         `json:"cohosts,omitempty" yaml:"cohosts,omitempty"`
+	Vars             map[string]any   `json:"vars,omitempty" yaml:"vars,omitempty"` // Default values of variables
+}
+
+// Templates is a set of templates indexed by name
+type Templates map[string]*Template
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilTemplate   = fmt.Errorf("Template is nil")
+	ErrEmptyTemplate = fmt.Errorf("Template name is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LoadTemplates loads templates from JSON file (use telemostyaml package for
+// loading templates from YAML)
+func LoadTemplates(path string) (Templates, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read templates: %w", err)
+	}
+
+	return ParseTemplates(data)
+}
+
+// ParseTemplates parses templates from JSON object where keys are template names
+func ParseTemplates(data []byte) (Templates, error) {
+	var templates Templates
+
+	err := json.Unmarshal(data, &templates)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode templates: %w", err)
+	}
+
+	err = templates.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns template with given name
+func (t Templates) Get(name string) (*Template, error) {
+	tmpl, ok := t[name]
+
+	if !ok {
+		return nil, fmt.Errorf("Unknown template %q", name)
+	}
+
+	return tmpl, nil
+}
+
+// Validate sets names of templates from their keys and validates them
+func (t Templates) Validate() error {
+	for _, name := range t.Names() {
+		tmpl := t[name]
+
+		if tmpl == nil {
+			return fmt.Errorf("Template %q is empty", name)
+		}
+
+		tmpl.Name = name
+		err := tmpl.Validate()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Names returns sorted names of templates
+func (t Templates) Names() []string {
+	return slices.Sorted(maps.Keys(t))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate checks template levels and patterns
+func (t *Template) Validate() error {
+	switch {
+	case t == nil:
+		return ErrNilTemplate
+	case t.Name == "":
+		return ErrEmptyTemplate
+	case !t.WaitingRoomLevel.IsZero() && !t.WaitingRoomLevel.isAllowed():
+		return fmt.Errorf("Template %q has unknown waiting room level %q", t.Name, t.WaitingRoomLevel.Raw())
+	case !t.AccessLevel.IsZero() && !t.AccessLevel.isAllowed():
+		return fmt.Errorf("Template %q has unknown live stream access level %q", t.Name, t.AccessLevel.Raw())
+	}
+
+	_, err := parsePattern(t.Name, "title", t.Title)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = parsePattern(t.Name, "description", t.Description)
+
+	return err
+}
+
+// Render renders conference using given variables. Variables override default
+// variables of template. Non-empty fields of overrides replace rendered values,
+// overrides cohosts are added to template cohosts.
+func (t *Template) Render(vars map[string]any, overrides *Conference) (*Conference, error) {
+	if t == nil {
+		return nil, ErrNilTemplate
+	}
+
+	err := t.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := maps.Clone(t.Vars)
+
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	maps.Copy(data, vars)
+
+	title, err := renderPattern(t.Name, "title", t.Title, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := renderPattern(t.Name, "description", t.Description, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &Conference{WaitingRoomLevel: t.WaitingRoomLevel}
+
+	if !t.AccessLevel.IsZero() || title != "" || description != "" {
+		conf.LiveStream = &LiveStream{
+			AccessLevel: t.AccessLevel,
+			Title:       title,
+			Description: description,
+		}
+	}
+
+	conf.WithCohosts(t.Cohosts...)
+	mergeConference(conf, overrides)
+
+	err = validateConference(conf)
+
+	if err != nil {
+		return nil, fmt.Errorf("Template %q: %w", t.Name, err)
+	}
+
+	return conf, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CreateFromTemplate creates new conference using given template
+func (c *Client) CreateFromTemplate(tmpl *Template, vars map[string]any, overrides *Conference) (*ConferenceInfo, error) {
+	return c.CreateFromTemplateContext(context.Background(), tmpl, vars, overrides)
+}
+
+// CreateFromTemplateContext creates new conference using given template and
+// context
+func (c *Client) CreateFromTemplateContext(ctx context.Context, tmpl *Template, vars map[string]any, overrides *Conference) (*ConferenceInfo, error) {
+	if c == nil || c.engine == nil {
+		return nil, ErrNilClient
+	}
+
+	conf, err := tmpl.Render(vars, overrides)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CreateContext(ctx, conf)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parsePattern parses template pattern
+func parsePattern(tmplName, field, pattern string) (*template.Template, error) {
+	t, err := template.New(field).Option("missingkey=error").Parse(pattern)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse %s of template %q: %w", field, tmplName, err)
+	}
+
+	return t, nil
+}
+
+// renderPattern renders template pattern with given variables
+func renderPattern(tmplName, field, pattern string, data map[string]any) (string, error) {
+	t, err := parsePattern(tmplName, field, pattern)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+
+	err = t.Execute(&buf, data)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't render %s of template %q: %w", field, tmplName, err)
+	}
+
+	return buf.String(), nil
+}
+
+// mergeConference applies non-empty fields of overrides to conference
+func mergeConference(conf, overrides *Conference) {
+	if overrides == nil {
+		return
+	}
+
+	if !overrides.WaitingRoomLevel.IsZero() {
+		conf.WaitingRoomLevel = overrides.WaitingRoomLevel
+	}
+
+	if overrides.LiveStream != nil {
+		if conf.LiveStream == nil {
+			conf.LiveStream = &LiveStream{}
+		}
+
+		if !overrides.LiveStream.AccessLevel.IsZero() {
+			conf.LiveStream.AccessLevel = overrides.LiveStream.AccessLevel
+		}
+
+		if overrides.LiveStream.Title != "" {
+			conf.LiveStream.Title = overrides.LiveStream.Title
+		}
+
+		if overrides.LiveStream.Description != "" {
+			conf.LiveStream.Description = overrides.LiveStream.Description
+		}
+	}
+
+	for _, email := range overrides.CoHosts.Flatten() {
+		if !slices.Contains(conf.CoHosts.Flatten(), email) {
+			conf.WithCohosts(email)
+		}
+	}
+}
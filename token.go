@@ -0,0 +1,324 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/req"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TokenSource is source of OAuth tokens consulted before every request
+type TokenSource interface {
+	// Token returns OAuth token
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenRefresher is token source which can refresh token if API rejected it
+type TokenRefresher interface {
+	TokenSource
+
+	// Refresh refreshes token rejected by API. It must do nothing if token was
+	// already changed since rejected one was returned, so concurrent requests
+	// rejected with the same token cause only one refresh.
+	Refresh(ctx context.Context, rejected string) error
+}
+
+// StaticToken is token source with static token
+type StaticToken string
+
+// EnvToken is token source which reads token from environment variable with given
+// name
+type EnvToken string
+
+// FileToken is token source which reads token from file and reloads it if file
+// was modified
+type FileToken struct {
+	path    string
+	token   string
+	modTime time.Time
+	mu      sync.Mutex
+}
+
+// OAuthToken is token source which uses Yandex OAuth refresh token flow
+type OAuthToken struct {
+	ClientID     string       // Application ID
+	ClientSecret string       // Application secret
+	URL          string       // Token endpoint URL (https://oauth.yandex.ru/token by default)
+	HTTPClient   *http.Client // HTTP client used for token requests
+
+	// OnRefresh is called after every successful refresh with new tokens, so they
+	// can be persisted. It is called without holding token lock, so it may call
+	// Token or Refresh. Calls for different refreshes may run concurrently.
+	OnRefresh func(accessToken, refreshToken string, expiry time.Time)
+
+	accessToken  string
+	refreshToken string
+	expiry       time.Time
+	mu           sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OAUTH_URL is default URL of Yandex OAuth token endpoint
+const OAUTH_URL = "https://oauth.yandex.ru/token"
+
+// tokenExpiryMargin is time before token expiration when it considered as expired
+const tokenExpiryMargin = time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilTokenSource   = fmt.Errorf("Token source is nil")
+	ErrEmptyPath        = fmt.Errorf("Path to token file is empty")
+	ErrEmptyVarName     = fmt.Errorf("Environment variable name is empty")
+	ErrEmptyRefresh     = fmt.Errorf("Refresh token is empty")
+	ErrEmptyCredentials = fmt.Errorf("Application ID or secret is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewFileToken creates new file token source
+func NewFileToken(path string) *FileToken {
+	return &FileToken{path: path}
+}
+
+// NewOAuthToken creates new OAuth token source
+func NewOAuthToken(clientID, clientSecret, accessToken, refreshToken string, expiry time.Time) *OAuthToken {
+	return &OAuthToken{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+		expiry:       expiry,
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Token returns static token
+func (t StaticToken) Token(_ context.Context) (string, error) {
+	if t == "" {
+		return "", ErrEmptyToken
+	}
+
+	return string(t), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Token returns token from environment variable
+func (t EnvToken) Token(_ context.Context) (string, error) {
+	if t == "" {
+		return "", ErrEmptyVarName
+	}
+
+	token := strings.TrimSpace(os.Getenv(string(t)))
+
+	if token == "" {
+		return "", fmt.Errorf("Environment variable %s is empty", string(t))
+	}
+
+	return token, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Token returns token from file
+func (t *FileToken) Token(_ context.Context) (string, error) {
+	if t == nil || t.path == "" {
+		return "", ErrEmptyPath
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info, err := os.Stat(t.path)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't read token file: %w", err)
+	}
+
+	if t.token != "" && info.ModTime().Equal(t.modTime) {
+		return t.token, nil
+	}
+
+	data, err := os.ReadFile(t.path)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't read token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+
+	if token == "" {
+		return "", fmt.Errorf("Token file %s is empty", t.path)
+	}
+
+	t.token, t.modTime = token, info.ModTime()
+
+	return t.token, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Token returns access token, refreshing it if it is expired
+func (t *OAuthToken) Token(ctx context.Context) (string, error) {
+	if t == nil {
+		return "", ErrNilTokenSource
+	}
+
+	t.mu.Lock()
+
+	if t.accessToken != "" && (t.expiry.IsZero() || time.Until(t.expiry) > tokenExpiryMargin) {
+		token := t.accessToken
+		t.mu.Unlock()
+		return token, nil
+	}
+
+	err := t.refresh(ctx)
+	token, refreshToken, expiry := t.accessToken, t.refreshToken, t.expiry
+
+	t.mu.Unlock()
+
+	if err != nil {
+		return "", err
+	}
+
+	t.notify(token, refreshToken, expiry)
+
+	return token, nil
+}
+
+// Refresh refreshes rejected access token using refresh token. If access token
+// was already refreshed by another request, it does nothing.
+func (t *OAuthToken) Refresh(ctx context.Context, rejected string) error {
+	if t == nil {
+		return ErrNilTokenSource
+	}
+
+	t.mu.Lock()
+
+	if t.accessToken != "" && t.accessToken != rejected {
+		t.mu.Unlock()
+		return nil
+	}
+
+	err := t.refresh(ctx)
+	token, refreshToken, expiry := t.accessToken, t.refreshToken, t.expiry
+
+	t.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	t.notify(token, refreshToken, expiry)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// refresh sends refresh token request to OAuth server
+func (t *OAuthToken) refresh(ctx context.Context) error {
+	switch {
+	case t.refreshToken == "":
+		return ErrEmptyRefresh
+	case t.ClientID == "" || t.ClientSecret == "":
+		return ErrEmptyCredentials
+	}
+
+	tokenURL, client := t.URL, t.HTTPClient
+
+	if tokenURL == "" {
+		tokenURL = OAUTH_URL
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {t.refreshToken},
+		"client_id":     {t.ClientID},
+		"client_secret": {t.ClientSecret},
+	}
+
+	r, err := http.NewRequestWithContext(
+		ctx, req.POST, tokenURL, strings.NewReader(form.Encode()),
+	)
+
+	if err != nil {
+		return fmt.Errorf("Can't create token request: %w", err)
+	}
+
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept", req.CONTENT_TYPE_JSON)
+
+	resp, err := client.Do(r)
+
+	if err != nil {
+		return fmt.Errorf("Can't send token request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	tokenResp := &struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+		Error        string `json:"error"`
+		Description  string `json:"error_description"`
+	}{}
+
+	err = json.NewDecoder(resp.Body).Decode(tokenResp)
+
+	switch {
+	case err != nil && resp.StatusCode > 299:
+		return fmt.Errorf("OAuth server returned non-ok status code %d", resp.StatusCode)
+	case err != nil:
+		return fmt.Errorf("Can't decode token response: %w", err)
+	case tokenResp.Error != "":
+		return fmt.Errorf("OAuth server returned error: %s (%s)", tokenResp.Description, tokenResp.Error)
+	case tokenResp.AccessToken == "":
+		return fmt.Errorf("OAuth server returned empty access token")
+	}
+
+	t.accessToken = tokenResp.AccessToken
+
+	if tokenResp.RefreshToken != "" {
+		t.refreshToken = tokenResp.RefreshToken
+	}
+
+	if tokenResp.ExpiresIn > 0 {
+		t.expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	} else {
+		t.expiry = time.Time{}
+	}
+
+	return nil
+}
+
+// notify passes refreshed tokens to OnRefresh callback
+func (t *OAuthToken) notify(accessToken, refreshToken string, expiry time.Time) {
+	if t.OnRefresh != nil {
+		t.OnRefresh(accessToken, refreshToken, expiry)
+	}
+}
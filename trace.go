@@ -0,0 +1,88 @@
+package telemost
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"net/http"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Tracer is interface for tracers of client operations and API requests
+//
+// One tracer is shared by all operations of client, including ones running in
+// parallel in batch methods. Ready-to-use OpenTelemetry tracer is available in
+// telemostotel package.
+type Tracer interface {
+	// StartOperation is called before high-level client operation. Conference ID
+	// is empty if it isn't known yet.
+	StartOperation(ctx context.Context, op, id string, cohosts int) (context.Context, Span)
+
+	// StartRequest is called before every attempt to send request to API
+	StartRequest(ctx context.Context, method, endpoint string, attempt int) (context.Context, Span)
+
+	// Inject adds trace context from given context to request headers
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is interface for traced operation or request
+type Span interface {
+	// SetConferenceID sets ID of conference created by operation
+	SetConferenceID(id string)
+
+	// SetCohostsCount sets number of cohosts processed by operation
+	SetCohostsCount(count int)
+
+	// End records result and ends span. Status code is 0 for operations and for
+	// requests without response.
+	End(statusCode int, err error)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// noopSpan is span used if tracer is not set
+type noopSpan struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetConferenceID is no-op
+func (noopSpan) SetConferenceID(id string) {}
+
+// SetCohostsCount is no-op
+func (noopSpan) SetCohostsCount(count int) {}
+
+// End is no-op
+func (noopSpan) End(statusCode int, err error) {}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startSpan starts span for high-level client operation
+func (c *Client) startSpan(ctx context.Context, operation, id string, cohosts int) (context.Context, Span) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	return c.tracer.StartOperation(ctx, operation, id, cohosts)
+}
+
+// startAttemptSpan starts span for single HTTP request to API
+func (c *Client) startAttemptSpan(ctx context.Context, method, endpoint string, attempt int) (context.Context, Span) {
+	if c.tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	return c.tracer.StartRequest(ctx, method, endpoint, attempt)
+}
+
+// injectTraceContext adds trace context headers to request
+func (c *Client) injectTraceContext(ctx context.Context, header http.Header) {
+	if c.tracer != nil {
+		c.tracer.Inject(ctx, header)
+	}
+}